@@ -1,15 +1,22 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
 	"runtime/debug"
+	"strings"
 
 	"github.com/spf13/afero"
 
 	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/check"
+	"github.com/masaushi/accessory/internal/testgen"
 )
 
 // Version is the version of `accessory`, injected at build time.
@@ -19,7 +26,9 @@ var Version = ""
 func newUsage(flags *flag.FlagSet) func() {
 	return func() {
 		fmt.Fprintf(os.Stderr, "Usage of accessory:\n")
-		fmt.Fprintf(os.Stderr, "\taccessory [flags] [directory]\n")
+		fmt.Fprintf(os.Stderr, "\taccessory [flags] [directories or packages, e.g. ./...]\n")
+		fmt.Fprintf(os.Stderr, "\taccessory enum [flags] [proto files]\n")
+		fmt.Fprintf(os.Stderr, "\taccessory proto [flags] [directory]\n")
 		fmt.Fprintf(os.Stderr, "For more information, see:\n")
 		fmt.Fprintf(os.Stderr, "\thttps://github.com/masaushi/accessory\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
@@ -29,70 +38,207 @@ func newUsage(flags *flag.FlagSet) func() {
 
 // Execute executes a whole process of generating accessor codes.
 func Execute(fs afero.Fs, args []string) {
+	if status := run(fs, os.Stdout, args); status != 0 {
+		os.Exit(status)
+	}
+}
+
+// run generates the accessors, writing -output -, -dry-run and -check to stdout, and returns the exit status.
+func run(fs afero.Fs, stdout io.Writer, args []string) int {
 	log.SetFlags(0 | log.Lshortfile)
 	log.SetPrefix("accessory: ")
 
+	if len(args) > 1 && args[1] == "enum" {
+		return runEnum(fs, stdout, args[1:])
+	}
+
+	if len(args) > 1 && args[1] == "proto" {
+		return runProto(fs, stdout, args[1:])
+	}
+
 	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
 	flags.Usage = newUsage(flags)
 	version := flags.Bool("version", false, "show the version of accessory")
-	typeName := flags.String("type", "", "type name; must be set")
+	typeName := flags.String("type", "",
+		"type name; default the structs with an accessor tag or the //accessory:generate directive")
 	lockName := flags.String("lock", "", "lock name")
 	receiver := flags.String("receiver", "", "receiver name; default first letter of type name")
-	output := flags.String("output", "", "output file name; default <type_name>_accessor.go")
+	output := flags.String("output", "", "output file name, - for the standard output; default <type_name>_accessor.go")
+	wrappers := flags.Bool("wrappers", false,
+		"convert pointers to scalars to the google.protobuf wrappers instead of optional fields; "+
+			"fields opt in one by one with the wrapper tag option, the only way to convert []byte")
+	testStyle := flags.String("test-style", testgen.StyleGt,
+		"style of the generated tests: "+strings.Join(testgen.Styles, ", ")+"; table uses the standard library only")
+
+	fuzz := flags.Bool("fuzz", false, "generate a fuzz test converting the primitive fields to proto and back")
+
+	bench := flags.Bool("bench", false, "generate benchmarks of the accessors and of the conversions")
+
+	checkOnly := flags.Bool("check", false,
+		"generate into memory and compare with the file on disk instead of writing it, printing a diff and exiting with 1 if it is stale; "+
+			"without -type, the accessor files of the structs which aren't targets anymore are stale too")
+
+	dryRun := flags.Bool("dry-run", false, "list the file and the methods that would be generated instead of writing it")
+
+	parallel := flags.Int("parallel", runtime.GOMAXPROCS(0), "number of structs generated at the same time")
+
+	var converters []accessor.Option
+	flags.Func("converter", "conversion of a model type, repeatable: "+
+		"<model type>=<to proto func>,<from proto func> with import paths, "+
+		"e.g. github.com/shopspring/decimal.Decimal=github.com/acme/money.DecimalToProto,github.com/acme/money.ProtoToDecimal; "+
+		"a function can be given the name to import its package with, e.g. money=github.com/acme/money/v2.DecimalToProto",
+		func(value string) error {
+			model, funcs, ok := strings.Cut(value, "=")
+			toProto, fromProto, ok2 := strings.Cut(funcs, ",")
+			if !ok || !ok2 || model == "" {
+				return fmt.Errorf("expected <model type>=<to proto func>,<from proto func>, got %q", value)
+			}
+
+			converters = append(converters, accessor.Converter(model, toProto, fromProto))
+
+			return nil
+		})
 
 	if err := flags.Parse(args[1:]); err != nil {
 		flags.Usage()
-		os.Exit(1)
+		return 1
 	}
 
 	if *version {
-		fmt.Fprintf(os.Stdout, "accessory version: %s\n", getVersion())
-		os.Exit(0)
+		fmt.Fprintf(stdout, "accessory version: %s\n", getVersion())
+		return 0
 	}
 
-	if typeName == nil || len(*typeName) == 0 {
+	if err := testgen.Validate(*testStyle); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		flags.Usage()
-		os.Exit(1)
+		return 1
 	}
 
-	var dir string
-	if cliArgs := flags.Args(); len(cliArgs) > 0 {
-		dir = cliArgs[0]
-	} else {
+	if *parallel < 1 {
+		fmt.Fprintln(os.Stderr, "-parallel must be at least 1")
+		flags.Usage()
+		return 1
+	}
+
+	// -check compares the files that would be written, there are none with the standard output.
+	if *checkOnly && (*output == "-" || *dryRun) {
+		fmt.Fprintln(os.Stderr, "-check can't be used with -output - nor -dry-run")
+		flags.Usage()
+		return 1
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
 		// Default: process whole package in current directory.
-		dir = "."
+		patterns = []string{"."}
 	}
 
-	if !isDir(dir) {
-		errorStr := fmt.Sprintf("Specified argument (%s) is not a directory.", dir)
-		fmt.Fprintln(os.Stderr, errorStr)
+	for i, pattern := range patterns {
+		// Directories are loaded by their absolute path, a relative one being an import path for go list.
+		if isDir(pattern) {
+			abs, err := filepath.Abs(pattern)
+			if err != nil {
+				log.Print(err)
+				return 1
+			}
+
+			patterns[i] = abs
+		}
+	}
+
+	pkgs, loadErr := accessor.ParsePackages(patterns...)
+	if len(pkgs) == 0 {
+		if loadErr == nil {
+			loadErr = fmt.Errorf("no packages matched %v", patterns)
+		}
+
+		fmt.Fprintln(os.Stderr, loadErr)
 		flags.Usage()
-		os.Exit(1)
+		return 1
 	}
 
-	pkg, err := accessor.ParsePackage(dir)
+	jobs, err := newJobs(pkgs, *typeName)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		flags.Usage()
-		os.Exit(1)
+		return 1
+	}
+
+	if len(jobs) > 1 && *output != "" && *output != "-" {
+		fmt.Fprintf(os.Stderr, "-output can't be used with %d structs to generate\n", len(jobs))
+		flags.Usage()
+		return 1
 	}
 
 	var options = []accessor.Option{
-		accessor.Type(*typeName),
 		accessor.Output(*output),
 		accessor.Receiver(*receiver),
 		accessor.Lock(*lockName),
+		accessor.Wrappers(*wrappers),
+		accessor.TestStyle(*testStyle),
+		accessor.Fuzz(*fuzz),
+		accessor.Bench(*bench),
+	}
+	options = append(options, converters...)
+
+	// sink is where the sources or the listings are written instead of the files, in the order of the jobs.
+	var sink io.Writer
+	if *output == "-" || *dryRun {
+		sink = stdout
+	}
+
+	if *dryRun {
+		options = append(options, accessor.DryRun(true))
+	}
+
+	target := fs
+	if *checkOnly {
+		target = afero.NewMemMapFs()
+	}
+
+	// The packages which failed to load are reported with the structs which failed to generate.
+	if err := errors.Join(loadErr, generate(target, sink, jobs, options, *parallel)); err != nil {
+		log.Print(err)
+		return 1
 	}
 
-	if err = accessor.Generate(fs, pkg, options...); err != nil {
-		log.Fatal(err)
+	if *checkOnly {
+		stale, err := check.Diff(fs, target, stdout, staleGlobs(pkgs, *typeName, *output)...)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+
+		if stale {
+			return 1
+		}
 	}
+
+	return 0
+}
+
+// staleGlobs returns the files -check expects to be generated when every struct of the packages is:
+// the <type_name>_accessor.go of their directories, those of the structs which aren't targets anymore being stale.
+// Nothing is expected when a type or an output is given, as the other files are generated by other runs.
+func staleGlobs(pkgs []*accessor.Package, typeName, output string) []string {
+	if typeName != "" || output != "" {
+		return nil
+	}
+
+	globs := make([]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		globs = append(globs, filepath.Join(pkg.Dir, "*_accessor.go"))
+	}
+
+	return globs
 }
 
+// isDir reports whether the argument is an existing directory rather than a package pattern.
 func isDir(name string) bool {
 	info, err := os.Stat(name)
 	if err != nil {
-		log.Fatal(err)
+		return false
 	}
 	return info.IsDir()
 }
@@ -1,6 +1,9 @@
 package cmd_test
 
 import (
+	"bytes"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -54,6 +57,63 @@ func TestExecute(t *testing.T) {
 			cmd:    "accessory -type Tester -lock lock testdata/with_lock",
 			output: "testdata/with_lock/tester_accessor.go",
 		},
+		"WithPointerLock": {
+			cmd:    "accessory -type Tester -lock lock testdata/with_pointer_lock",
+			output: "testdata/with_pointer_lock/tester_accessor.go",
+		},
+		"Oneof": {
+			cmd:    "accessory -type Tester testdata/oneof",
+			output: "testdata/oneof/tester_accessor.go",
+		},
+		"NestedModels": {
+			cmd:    "accessory -type Tester testdata/nested",
+			output: "testdata/nested/tester_accessor.go",
+		},
+		"EnumFields": {
+			cmd:    "accessory -type Tester testdata/enum_fields",
+			output: "testdata/enum_fields/tester_accessor.go",
+		},
+		"WellKnownTypes": {
+			cmd:    "accessory -type Tester testdata/well_known",
+			output: "testdata/well_known/tester_accessor.go",
+		},
+		"WellKnownTypesWithWrappers": {
+			cmd: "accessory -type Tester -wrappers -output wrappers_accessor.go " +
+				"-converter github.com/masaushi/accessory/cmd/testdata/well_known/money.Money=" +
+				"github.com/acme/moneypb.MoneyToProto,github.com/acme/moneypb.ProtoToMoney testdata/well_known",
+			output: "testdata/well_known/wrappers_accessor.go",
+		},
+		// The package of .../converter/v2 is found to be moneyconv, durations is guessed from the gopkg.in
+		// path which can't be loaded, and the package of ProtoToMoney is imported with the given name.
+		"ConverterPackageNames": {
+			cmd: "accessory -type Tester -output converter_accessor.go " +
+				"-converter github.com/masaushi/accessory/cmd/testdata/well_known/money.Money=" +
+				"github.com/masaushi/accessory/cmd/testdata/converter/v2.MoneyToProto," +
+				"conv=github.com/masaushi/accessory/cmd/testdata/converter/v2.ProtoToMoney " +
+				"-converter time.Duration=gopkg.in/acme/durations.v3.ToProto,gopkg.in/acme/durations.v3.FromProto " +
+				"testdata/well_known",
+			output: "testdata/well_known/converter_accessor.go",
+		},
+		"TableTestStyle": {
+			cmd:    "accessory -type Tester -test-style table -output table_accessor.go testdata/oneof",
+			output: "testdata/oneof/table_accessor.go",
+		},
+		"TestifyTestStyle": {
+			cmd:    "accessory -type Tester -test-style testify -output testify_accessor.go testdata/oneof",
+			output: "testdata/oneof/testify_accessor.go",
+		},
+		"Fuzz": {
+			cmd:    "accessory -type Tester -fuzz testdata/fuzz",
+			output: "testdata/fuzz/tester_accessor.go",
+		},
+		"Bench": {
+			cmd:    "accessory -type Tester -lock lock -bench -output bench_accessor.go testdata/with_lock",
+			output: "testdata/with_lock/bench_accessor.go",
+		},
+		"NoTestStyle": {
+			cmd:    "accessory -type Tester -test-style none -output none_accessor.go testdata/oneof",
+			output: "testdata/oneof/none_accessor.go",
+		},
 	}
 
 	fs := afero.NewMemMapFs()
@@ -89,3 +149,354 @@ func TestExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestExecute_Patterns(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cmd     string
+		outputs []string
+		skipped []string
+	}{
+		"Discover": {
+			cmd: "accessory -parallel 2 ./testdata/discover/...",
+			outputs: []string{
+				"testdata/discover/order/order_accessor.go",
+				"testdata/discover/item/item_accessor.go",
+			},
+			skipped: []string{
+				"testdata/discover/order/note_accessor.go",
+			},
+		},
+		"TypeInPatterns": {
+			cmd: "accessory -type Tester -test-style none ./testdata/getter ./testdata/setter",
+			outputs: []string{
+				"testdata/getter/tester_accessor.go",
+				"testdata/setter/tester_accessor.go",
+			},
+		},
+	}
+
+	snapshot := cupaloy.New(
+		cupaloy.SnapshotSubdirectory("testdata/.snapshots"),
+		cupaloy.SnapshotFileExtension(".go"),
+	)
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			cmd.Execute(fs, strings.Split(tt.cmd, " "))
+
+			files := make([]interface{}, 0, len(tt.outputs))
+			for _, output := range tt.outputs {
+				output, _ := filepath.Abs(output)
+
+				file, err := afero.ReadFile(fs, output)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				files = append(files, file)
+			}
+
+			for _, skipped := range tt.skipped {
+				skipped, _ := filepath.Abs(skipped)
+
+				exists, err := afero.Exists(fs, skipped)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if exists {
+					t.Fatalf("file %s generated", skipped)
+				}
+			}
+
+			snapshot.SnapshotT(t, files...)
+		})
+	}
+}
+
+func TestExecute_Stdout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cmd string
+	}{
+		"DryRun": {
+			cmd: "accessory -type Tester -dry-run testdata/getter_and_setter",
+		},
+		"Output": {
+			cmd: "accessory -type Tester -output - testdata/getter_and_setter",
+		},
+		// The structs are written in the order they are found in, whichever is generated first.
+		"DiscoverDryRun": {
+			cmd: "accessory -dry-run -parallel 2 ./testdata/discover/...",
+		},
+		"DiscoverOutput": {
+			cmd: "accessory -output - -test-style none -parallel 2 ./testdata/discover/...",
+		},
+	}
+
+	snapshot := cupaloy.New(cupaloy.SnapshotSubdirectory("testdata/.snapshots"))
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			out := new(bytes.Buffer)
+
+			if status := cmd.Run(fs, out, strings.Split(tt.cmd, " ")); status != 0 {
+				t.Fatalf("exited with %d", status)
+			}
+
+			// Nothing is written, the listing or the source only going to the standard output.
+			if err := afero.Walk(fs, string(filepath.Separator), func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					t.Errorf("file %s written", path)
+				}
+
+				return err
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			wd, _ := filepath.Abs(".")
+			snapshot.SnapshotT(t, strings.ReplaceAll(out.String(), wd+string(filepath.Separator), ""))
+		})
+	}
+}
+
+func TestExecute_PackageErrors(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	args := []string{"accessory", "./testdata/discover/...", "./testdata/broken", "./testdata/type_error"}
+	if status := cmd.Run(fs, io.Discard, args); status != 1 {
+		t.Errorf("status = %d, want 1", status)
+	}
+
+	// The packages which loaded are generated anyway, the one failing to type check too.
+	for _, output := range []string{
+		"testdata/discover/order/order_accessor.go",
+		"testdata/discover/item/item_accessor.go",
+		"testdata/type_error/tester_accessor.go",
+	} {
+		output, _ := filepath.Abs(output)
+
+		exists, err := afero.Exists(fs, output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Errorf("file %s not generated", output)
+		}
+	}
+}
+
+func TestExecute_UnsupportedWrapper(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		typ    string
+		output string
+	}{
+		"Time": {
+			typ:    "TimeTester",
+			output: "testdata/unsupported_wrapper/timetester_accessor.go",
+		},
+		"Slice": {
+			typ:    "SliceTester",
+			output: "testdata/unsupported_wrapper/slicetester_accessor.go",
+		},
+		"Struct": {
+			typ:    "StructTester",
+			output: "testdata/unsupported_wrapper/structtester_accessor.go",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			args := []string{"accessory", "-type", tt.typ, "testdata/unsupported_wrapper"}
+			if status := cmd.Run(fs, io.Discard, args); status != 1 {
+				t.Errorf("status = %d, want 1", status)
+			}
+
+			output, _ := filepath.Abs(tt.output)
+
+			exists, err := afero.Exists(fs, output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists {
+				t.Errorf("file %s generated", output)
+			}
+		})
+	}
+}
+
+func TestExecute_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cmd string
+		// edits are written after generating the files with the command, before checking them.
+		edits      map[string]string
+		wantStatus int
+		// wantDiff are the headers of the file diffs -check prints, with the paths relative to the test.
+		wantDiff []string
+	}{
+		"UpToDate": {
+			cmd: "accessory -type Tester testdata/getter",
+		},
+		"Stale": {
+			cmd:        "accessory -type Tester testdata/getter",
+			edits:      map[string]string{"testdata/getter/tester_accessor.go": "package test\n"},
+			wantStatus: 1,
+			wantDiff:   []string{"--- testdata/getter/tester_accessor.go\n+++ testdata/getter/tester_accessor.go\n"},
+		},
+		// note_accessor.go was generated before Note lost its tag, it is stale as Note isn't a target anymore.
+		"Extra": {
+			cmd: "accessory ./testdata/discover/...",
+			edits: map[string]string{
+				"testdata/discover/order/note_accessor.go": "// Code generated by accessory; DO NOT EDIT.\n\npackage order\n",
+				"testdata/discover/order/hand_accessor.go": "package order\n",
+			},
+			wantStatus: 1,
+			wantDiff:   []string{"--- testdata/discover/order/note_accessor.go\n+++ /dev/null\n"},
+		},
+		"DryRun": {
+			cmd:        "accessory -type Tester -dry-run testdata/getter",
+			wantStatus: 1,
+		},
+		"Stdout": {
+			cmd:        "accessory -type Tester -output - testdata/getter",
+			wantStatus: 1,
+		},
+		"EnumUpToDate": {
+			cmd: "accessory enum -out testdata/enum/out testdata/enum/state.proto",
+		},
+		"EnumStale": {
+			cmd:        "accessory enum -out testdata/enum/out testdata/enum/state.proto",
+			edits:      map[string]string{"testdata/enum/out/State.go": "package input_enum\n"},
+			wantStatus: 1,
+			wantDiff:   []string{"--- testdata/enum/out/State.go\n+++ testdata/enum/out/State.go\n"},
+		},
+		"ProtoUpToDate": {
+			cmd: "accessory proto -type Order -go-package example.com/gen/shop/v1;shopv1 testdata/proto",
+		},
+		"ProtoStale": {
+			cmd:        "accessory proto -type Order -go-package example.com/gen/shop/v1;shopv1 testdata/proto",
+			edits:      map[string]string{"testdata/proto/shop.proto": "syntax = \"proto3\";\n"},
+			wantStatus: 1,
+			wantDiff:   []string{"--- testdata/proto/shop.proto\n+++ testdata/proto/shop.proto\n"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			args := strings.Split(tt.cmd, " ")
+
+			if status := cmd.Run(fs, io.Discard, args); status != 0 {
+				t.Fatalf("generating exited with %d", status)
+			}
+
+			for path, content := range tt.edits {
+				path, _ := filepath.Abs(path)
+
+				if err := afero.WriteFile(fs, path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// -check follows the enum and proto subcommands, whose flags come after them.
+			at := 1
+			if args[1] == "enum" || args[1] == "proto" {
+				at = 2
+			}
+
+			out := new(bytes.Buffer)
+			status := cmd.Run(fs, out, append(append(append([]string{}, args[:at]...), "-check"), args[at:]...))
+
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+
+			wd, _ := filepath.Abs(".")
+			diff := strings.ReplaceAll(out.String(), wd+string(filepath.Separator), "")
+
+			if len(tt.wantDiff) == 0 && diff != "" {
+				t.Errorf("unexpected diff:\n%s", diff)
+			}
+
+			for _, want := range tt.wantDiff {
+				if !strings.Contains(diff, want) {
+					t.Errorf("diff doesn't have %q:\n%s", want, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestExecute_Proto(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cmd string
+		// lock is written to the lock file before generating, empty for none.
+		lock string
+	}{
+		"NewLock": {
+			cmd: "accessory proto -type Order -go-package example.com/gen/shop/v1;shopv1 testdata/proto",
+		},
+		// The lock keeps the number of note, and reserves the one of the removed total.
+		"ExistingLock": {
+			cmd:  "accessory proto -type Order -go-package example.com/gen/shop/v1;shopv1 testdata/proto",
+			lock: `{"messages": {"Order": {"fields": {"id": 1, "total": 2, "note": 3}}}}`,
+		},
+	}
+
+	snapshot := cupaloy.New(cupaloy.SnapshotSubdirectory("testdata/.snapshots"))
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			output, _ := filepath.Abs("testdata/proto/shop.proto")
+
+			fs := afero.NewMemMapFs()
+			if tt.lock != "" {
+				if err := afero.WriteFile(fs, output+".lock", []byte(tt.lock), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd.Execute(fs, strings.Split(tt.cmd, " "))
+
+			files := make([]interface{}, 0, 2)
+			for _, path := range []string{output, output + ".lock"} {
+				file, err := afero.ReadFile(fs, path)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				files = append(files, file)
+			}
+
+			snapshot.SnapshotT(t, files...)
+		})
+	}
+}
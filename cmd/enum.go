@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/check"
+	"github.com/masaushi/accessory/internal/enum"
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+// enumDir is the proto file generated from when accessory enum is given none.
+const enumDir = "./input-enum/input.proto"
+
+// stringList is a flag.Value collecting every occurrence of a repeatable flag, like -I.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
+
+// ExecuteEnum executes the enum generator, given the arguments after accessory or the name of its own binary,
+// and returns the exit status.
+func ExecuteEnum(fs afero.Fs, args []string) int {
+	return runEnum(fs, os.Stdout, args)
+}
+
+// runEnum generates the enums, writing the diff of -check to stdout, and returns the exit status.
+func runEnum(fs afero.Fs, stdout io.Writer, args []string) int {
+	log.SetFlags(0 | log.Lshortfile)
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+
+	var includes stringList
+
+	flags.Var(&includes, "I", "include path imports are looked up in, can be repeated; default current directory")
+	descriptorSet := flags.String("descriptor-set", "",
+		"binary FileDescriptorSet to read the enums and messages from instead of parsing proto files (protoc -o set.pb --include_source_info); "+
+			"arguments then name the files of the set to generate, default every file")
+	out := flags.String("out", "./input-enum", "directory the generated files are written to")
+	layout := flags.String("layout", enum.LayoutEnum,
+		"output layout: enum (one Go file per enum and message) or file (one Go file per proto file)")
+	with := flags.String("with", "", "comma separated method families to generate: string,json,text,sql,yaml")
+	nameStyle := flags.String("name-style", enum.NameStyleProto,
+		"names used by String/Parse: proto (REMINDER_STATE_STARTED) or lower (reminder_state_started)")
+	strict := flags.Bool("strict", false,
+		"generate IsValid, ProtoTo<Enum>Strict and a test failing when a proto value has no model counterpart")
+	flagsEnums := flags.String("flags", "",
+		"comma separated enums to generate as bit flags, converted to and from a repeated proto enum field; "+
+			"enums with option "+enum.FlagsOption+" = true are always bit flags")
+	emit := flags.String("emit", enum.EmitGo, "comma separated backends to generate: go,ts,jsonschema,sql")
+	sqlPrevious := flags.String("sql-previous", "",
+		"DDL generated by an earlier run, a .sql file or a directory of them; the sql backend then also writes "+
+			enum.SQLMigrationFile+" with the ALTER TYPE statements for the new values")
+	testStyle := flags.String("test-style", testgen.StyleGt,
+		"style of the generated tests: "+strings.Join(testgen.Styles, ", ")+"; table uses the standard library only")
+	fuzz := flags.Bool("fuzz", false, "generate a fuzz test checking the conversions of any proto number are stable")
+	reverse := flags.String("reverse", "",
+		"Go package directory to generate a proto file from: every named integer type with constants becomes a proto3 enum, "+
+			"written to -out with the Go conversions and their test")
+	goPackage := flags.String("go-package", "", "go_package option of the proto file generated by -reverse, e.g. example.com/gen/order/v1;orderv1")
+	reversePackage := flags.String("reverse-package", "", "package of the proto file generated by -reverse, default the Go package name")
+	checkOnly := flags.Bool("check", false,
+		"generate into memory and compare with the files in -out instead of writing them, printing a diff and exiting with 1 if any is stale")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	// -out is absolute for -check to compare the files at the same paths in memory and on disk.
+	abs, err := filepath.Abs(*out)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	*out = abs
+
+	// target is where the files are written, in memory to be compared with the ones on disk with -check.
+	target := fs
+	if *checkOnly {
+		target = afero.NewMemMapFs()
+	}
+
+	// finish checks the files written to target with -check, every file generated by accessory in -out
+	// must be generated again.
+	finish := func() int {
+		if !*checkOnly {
+			return 0
+		}
+
+		return checkOutputs(fs, target, stdout, filepath.Join(*out, "*"))
+	}
+
+	if *reverse != "" {
+		pkg, err := accessor.ParsePackage(*reverse)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+
+		outputs, err := enum.GenerateReverseFiles(pkg, &enum.ReverseOptions{
+			GoPackage:    *goPackage,
+			ProtoPackage: *reversePackage,
+			TestStyle:    *testStyle,
+			Fuzz:         *fuzz,
+		})
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+
+		if err := writeOutputs(target, *out, outputs); err != nil {
+			log.Print(err)
+			return 1
+		}
+
+		return finish()
+	}
+
+	families, err := enum.ParseMethodFamilies(*with)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	emitters, err := enum.ParseEmitters(*emit)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	opts := &enum.Options{
+		Families:  families,
+		NameStyle: *nameStyle,
+		Strict:    *strict,
+		Layout:    *layout,
+		Flags:     enum.ParseFlagsEnums(*flagsEnums),
+		Emit:      emitters,
+		TestStyle: *testStyle,
+		Fuzz:      *fuzz,
+	}
+
+	if *sqlPrevious != "" {
+		opts.PreviousSQL, err = readSQL(fs, *sqlPrevious)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+	}
+
+	if err := opts.Validate(); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	var files []*enum.ProtoFile
+
+	if *descriptorSet != "" {
+		files, err = enum.LoadDescriptorSet(*descriptorSet, flags.Args())
+	} else {
+		// Inputs are proto files or directories holding them.
+		inputs := flags.Args()
+		if len(inputs) == 0 {
+			inputs = []string{enumDir}
+		}
+
+		files, err = enum.LoadProtoFiles(inputs, includes)
+	}
+
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	outputs, err := enum.GenerateFiles(files, opts)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	if err := writeOutputs(target, *out, outputs); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	return finish()
+}
+
+// writeOutputs writes the generated files under the output directory.
+func writeOutputs(fs afero.Fs, out string, outputs []*enum.OutputFile) error {
+	for _, output := range outputs {
+		path := filepath.Join(out, output.Name)
+
+		if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		if err := afero.WriteFile(fs, path, output.Content, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkOutputs prints the diff of the generated files with the ones on disk to w, returning 1 if any is stale.
+// The files generated by accessory matching the globs are stale when they weren't generated again.
+func checkOutputs(disk, generated afero.Fs, w io.Writer, globs ...string) int {
+	stale, err := check.Diff(disk, generated, w, globs...)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	if stale {
+		return 1
+	}
+
+	return 0
+}
+
+// readSQL reads the .sql file, or every .sql file of the directory, the migration itself apart.
+func readSQL(fs afero.Fs, path string) (string, error) {
+	info, err := fs.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	paths := []string{path}
+	if info.IsDir() {
+		paths, err = afero.Glob(fs, filepath.Join(path, "*.sql"))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var ddl strings.Builder
+
+	for _, p := range paths {
+		if info.IsDir() && filepath.Base(p) == enum.SQLMigrationFile {
+			continue
+		}
+
+		content, err := afero.ReadFile(fs, p)
+		if err != nil {
+			return "", err
+		}
+
+		ddl.Write(content)
+		ddl.WriteString("\n")
+	}
+
+	return ddl.String(), nil
+}
@@ -0,0 +1,4 @@
+package cmd
+
+// Run runs the accessor generator with the standard output of the tests, returning the exit status.
+var Run = run
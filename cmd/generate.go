@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/internal/accessor"
+)
+
+// job is the generation of the accessors of a struct of a package.
+type job struct {
+	pkg      *accessor.Package
+	typeName string
+}
+
+// newJobs lists the structs to generate: the type in every package declaring it,
+// or the structs discovered in each package when no type is given.
+func newJobs(pkgs []*accessor.Package, typeName string) ([]*job, error) {
+	jobs := make([]*job, 0)
+
+	for _, pkg := range pkgs {
+		if typeName != "" {
+			if pkg.Lookup(typeName) != nil {
+				jobs = append(jobs, &job{pkg: pkg, typeName: typeName})
+			}
+
+			continue
+		}
+
+		for _, st := range pkg.Targets() {
+			jobs = append(jobs, &job{pkg: pkg, typeName: st.Name})
+		}
+	}
+
+	if len(jobs) == 0 {
+		if typeName != "" {
+			return nil, fmt.Errorf("type %s not found", typeName)
+		}
+
+		return nil, errors.New("no struct with an accessor tag or the //accessory:generate directive found")
+	}
+
+	return jobs, nil
+}
+
+// generate runs the jobs with at most parallel of them at the same time,
+// returning the errors of all of them in the order of the jobs. With a sink, the output of every job
+// is buffered and written to it in the order of the jobs once they are all done, whatever order they ran in.
+func generate(fs afero.Fs, sink io.Writer, jobs []*job, options []accessor.Option, parallel int) error {
+	errs := make([]error, len(jobs))
+	outputs := make([]*bytes.Buffer, len(jobs))
+	queue := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				j := jobs[i]
+				opts := append([]accessor.Option{accessor.Type(j.typeName)}, options...)
+
+				if sink != nil {
+					outputs[i] = new(bytes.Buffer)
+					opts = append(opts, accessor.Sink(outputs[i]))
+				}
+
+				if err := accessor.Generate(fs, j.pkg, opts...); err != nil {
+					errs[i] = fmt.Errorf("%s.%s: %w", j.pkg.PkgPath, j.typeName, err)
+				}
+			}
+		}()
+	}
+
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	for _, output := range outputs {
+		if output == nil {
+			continue
+		}
+
+		if _, err := output.WriteTo(sink); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+// ExecuteProto executes the generator of proto3 messages from Go structs,
+// given the arguments after accessory or the name of its own binary, and returns the exit status.
+func ExecuteProto(fs afero.Fs, args []string) int {
+	return runProto(fs, os.Stdout, args)
+}
+
+// runProto generates the proto file, writing the diff of -check to stdout, and returns the exit status.
+func runProto(fs afero.Fs, stdout io.Writer, args []string) int {
+	log.SetFlags(0 | log.Lshortfile)
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+
+	typeNames := flags.String("type", "", "comma separated structs to generate a message for; must be set")
+	goPackage := flags.String("go-package", "",
+		"go_package option of the proto file, e.g. example.com/gen/order/v1;orderv1; must be set")
+	protoPackage := flags.String("package", "", "package of the proto file; default the Go package name")
+	output := flags.String("output", "", "proto file to write; default <package name>.proto in the directory")
+	lockFile := flags.String("lock-file", "", "file persisting the field numbers; default the output file with .lock appended")
+	checkOnly := flags.Bool("check", false,
+		"generate into memory and compare with the proto and lock files on disk instead of writing them, "+
+			"printing a diff and exiting with 1 if any is stale")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	if *typeNames == "" {
+		flags.Usage()
+		log.Print("-type must be set")
+		return 1
+	}
+
+	dir := "."
+	if args := flags.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	pkg, err := accessor.ParsePackage(dir)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	if *output == "" {
+		*output = filepath.Join(pkg.Dir, pkg.Name+".proto")
+	}
+
+	// Paths are absolute for -check to compare the files at the same paths in memory and on disk.
+	if *output, err = filepath.Abs(*output); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	if *lockFile == "" {
+		*lockFile = *output + ".lock"
+	}
+
+	if *lockFile, err = filepath.Abs(*lockFile); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	// The lock is read from disk even with -check, the files being compared with the ones it numbered.
+	lock, err := enum.LoadFieldLock(fs, *lockFile)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	content, err := enum.GenerateProtoMessages(pkg, lock, &enum.MessageOptions{
+		Types:        strings.Split(*typeNames, ","),
+		GoPackage:    *goPackage,
+		ProtoPackage: *protoPackage,
+	})
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	lockContent, err := lock.Marshal()
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	target := fs
+	if *checkOnly {
+		target = afero.NewMemMapFs()
+	}
+
+	for path, content := range map[string][]byte{*output: content, *lockFile: lockContent} {
+		if err := target.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			log.Print(err)
+			return 1
+		}
+
+		if err := afero.WriteFile(target, path, content, 0644); err != nil {
+			log.Print(err)
+			return 1
+		}
+	}
+
+	if *checkOnly {
+		return checkOutputs(fs, target, stdout)
+	}
+
+	return 0
+}
@@ -2,10 +2,17 @@
 
 package test
 
-func (t *Tester) FirstField() string {
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// GetFirstField returns the Tester's firstField.
+func (t *Tester) GetFirstField() string {
 	if t == nil {
 		return ""
 	}
+
 	return t.firstField
 }
 
@@ -16,10 +23,12 @@ func (t *Tester) SetSecondField(val int32) {
 	t.secondField = val
 }
 
-func (t *Tester) ThirdField() int32 {
+// GetThirdField returns the Tester's thirdField.
+func (t *Tester) GetThirdField() int32 {
 	if t == nil {
 		return 0
 	}
+
 	return t.thirdField
 }
 
@@ -30,3 +39,158 @@ func (t *Tester) SetThirdField(val int32) {
 	t.thirdField = val
 }
 
+// ToProto converts Tester to the Protobuf version.
+func (tester *Tester) ToProto() *replaceMe.Tester {
+	if tester == nil {
+		return nil
+	}
+
+	result := &replaceMe.Tester{
+		FirstField:  tester.firstField,
+		SecondField: tester.secondField,
+		ThirdField:  tester.thirdField,
+	}
+
+	return result
+}
+
+// ProtoToTester converts from Protobuf version to the Tester.
+func ProtoToTester(tester *replaceMe.Tester) *Tester {
+	if tester == nil {
+		return nil
+	}
+
+	result := &Tester{
+		firstField:  tester.FirstField,
+		secondField: tester.SecondField,
+		thirdField:  tester.ThirdField,
+	}
+
+	return result
+}
+
+// TestersToProto converts a slice of Tester to the Protobuf version.
+func TestersToProto(items []*Tester) []*replaceMe.Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]*replaceMe.Tester, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.ToProto())
+	}
+
+	return result
+}
+
+// ProtoToTesters converts a slice of the Protobuf version to Tester.
+func ProtoToTesters(items []*replaceMe.Tester) []*Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]*Tester, 0, len(items))
+	for _, item := range items {
+		result = append(result, ProtoToTester(item))
+	}
+
+	return result
+}
+
+// TesterMapToProto converts a map of Tester to the Protobuf version.
+func TesterMapToProto[K comparable](items map[K]*Tester) map[K]*replaceMe.Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make(map[K]*replaceMe.Tester, len(items))
+	for key, item := range items {
+		result[key] = item.ToProto()
+	}
+
+	return result
+}
+
+// ProtoToTesterMap converts a map of the Protobuf version to Tester.
+func ProtoToTesterMap[K comparable](items map[K]*replaceMe.Tester) map[K]*Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make(map[K]*Tester, len(items))
+	for key, item := range items {
+		result[key] = ProtoToTester(item)
+	}
+
+	return result
+}
+
+func TestTester_GetFunctions(t *testing.T) {
+	type want struct {
+		args           *models.Tester
+		wantfirstField string
+		wantthirdField int32
+		wantProto      *replaceMe.Tester
+	}
+
+	type Context struct {
+		testData *want
+	}
+
+	contextInitiateFunction := func(t *testing.T) *Context {
+		return &Context{}
+	}
+
+	gt.Begin(t,
+		contextInitiateFunction,
+		gt.Run("Get functions return proper value", func(t *testing.T, ctx *Context) {
+			// GET functions
+			gotfirstField := ctx.testData.args.GetFirstField()
+			assert.Equal(t, ctx.testData.wantfirstField, gotfirstField)
+
+			gotthirdField := ctx.testData.args.GetThirdField()
+			assert.Equal(t, ctx.testData.wantthirdField, gotthirdField)
+
+			// Convert from models to Proto.
+			gotProto := ctx.testData.args.ToProto()
+			assert.Equal(t, ctx.testData.wantProto, gotProto)
+
+			// Then convert from Proto back to model
+			gotModel := models.ProtoToTester(gotProto)
+			assert.Equal(t, ctx.testData.args, gotModel)
+		}).
+			Using("given nil value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args:           nil,
+					wantfirstField: "",
+					wantthirdField: 0,
+					wantProto:      nil,
+				}
+			}).
+			Using("given empty value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args:           &models.Tester{},
+					wantfirstField: "",
+					wantthirdField: 0,
+					wantProto:      &replaceMe.Tester{},
+				}
+			}).
+			Using("given NON nil value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args: models.ProtoToTester(&replaceMe.Tester{
+						FirstField:  "firstField",
+						SecondField: 2,
+						ThirdField:  3,
+					}),
+					wantfirstField: "firstField",
+					wantthirdField: 3,
+					wantProto: &replaceMe.Tester{
+						FirstField:  "firstField",
+						SecondField: 2,
+						ThirdField:  3,
+					},
+				}
+			}),
+	)
+}
+
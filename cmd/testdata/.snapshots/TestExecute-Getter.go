@@ -2,17 +2,197 @@
 
 package test
 
-func (t *Tester) Field1() string {
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// GetField1 returns the Tester's field1.
+func (t *Tester) GetField1() string {
 	if t == nil {
 		return ""
 	}
+
 	return t.field1
 }
 
+// GetSecondField returns the Tester's field2.
 func (t *Tester) GetSecondField() int32 {
 	if t == nil {
 		return 0
 	}
+
 	return t.field2
 }
 
+// GetField3 returns the Tester's field3.
+func (t *Tester) GetField3() *bool {
+	if t == nil {
+		return nil
+	}
+
+	return t.field3
+}
+
+// ToProto converts Tester to the Protobuf version.
+func (tester *Tester) ToProto() *replaceMe.Tester {
+	if tester == nil {
+		return nil
+	}
+
+	result := &replaceMe.Tester{
+		Field1: tester.field1,
+		Field2: tester.field2,
+		Field3: tester.field3,
+	}
+
+	return result
+}
+
+// ProtoToTester converts from Protobuf version to the Tester.
+func ProtoToTester(tester *replaceMe.Tester) *Tester {
+	if tester == nil {
+		return nil
+	}
+
+	result := &Tester{
+		field1: tester.Field1,
+		field2: tester.Field2,
+		field3: tester.Field3,
+	}
+
+	return result
+}
+
+// TestersToProto converts a slice of Tester to the Protobuf version.
+func TestersToProto(items []*Tester) []*replaceMe.Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]*replaceMe.Tester, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.ToProto())
+	}
+
+	return result
+}
+
+// ProtoToTesters converts a slice of the Protobuf version to Tester.
+func ProtoToTesters(items []*replaceMe.Tester) []*Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]*Tester, 0, len(items))
+	for _, item := range items {
+		result = append(result, ProtoToTester(item))
+	}
+
+	return result
+}
+
+// TesterMapToProto converts a map of Tester to the Protobuf version.
+func TesterMapToProto[K comparable](items map[K]*Tester) map[K]*replaceMe.Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make(map[K]*replaceMe.Tester, len(items))
+	for key, item := range items {
+		result[key] = item.ToProto()
+	}
+
+	return result
+}
+
+// ProtoToTesterMap converts a map of the Protobuf version to Tester.
+func ProtoToTesterMap[K comparable](items map[K]*replaceMe.Tester) map[K]*Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make(map[K]*Tester, len(items))
+	for key, item := range items {
+		result[key] = ProtoToTester(item)
+	}
+
+	return result
+}
+
+func TestTester_GetFunctions(t *testing.T) {
+	type want struct {
+		args       *models.Tester
+		wantfield1 string
+		wantfield2 int32
+		wantfield3 *bool
+		wantProto  *replaceMe.Tester
+	}
+
+	type Context struct {
+		testData *want
+	}
+
+	contextInitiateFunction := func(t *testing.T) *Context {
+		return &Context{}
+	}
+
+	gt.Begin(t,
+		contextInitiateFunction,
+		gt.Run("Get functions return proper value", func(t *testing.T, ctx *Context) {
+			// GET functions
+			gotfield1 := ctx.testData.args.GetField1()
+			assert.Equal(t, ctx.testData.wantfield1, gotfield1)
+
+			gotfield2 := ctx.testData.args.GetSecondField()
+			assert.Equal(t, ctx.testData.wantfield2, gotfield2)
+
+			gotfield3 := ctx.testData.args.GetField3()
+			assert.Equal(t, ctx.testData.wantfield3, gotfield3)
+
+			// Convert from models to Proto.
+			gotProto := ctx.testData.args.ToProto()
+			assert.Equal(t, ctx.testData.wantProto, gotProto)
+
+			// Then convert from Proto back to model
+			gotModel := models.ProtoToTester(gotProto)
+			assert.Equal(t, ctx.testData.args, gotModel)
+		}).
+			Using("given nil value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args:       nil,
+					wantfield1: "",
+					wantfield2: 0,
+					wantfield3: nil,
+					wantProto:  nil,
+				}
+			}).
+			Using("given empty value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args:       &models.Tester{},
+					wantfield1: "",
+					wantfield2: 0,
+					wantfield3: nil,
+					wantProto:  &replaceMe.Tester{},
+				}
+			}).
+			Using("given NON nil value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args: models.ProtoToTester(&replaceMe.Tester{
+						Field1: "field1",
+						Field2: 2,
+						Field3: func() *bool { var v bool = true; return &v }(),
+					}),
+					wantfield1: "field1",
+					wantfield2: 2,
+					wantfield3: func() *bool { var v bool = true; return &v }(),
+					wantProto: &replaceMe.Tester{
+						Field1: "field1",
+						Field2: 2,
+						Field3: func() *bool { var v bool = true; return &v }(),
+					},
+				}
+			}),
+	)
+}
+
@@ -6,13 +6,18 @@ import (
 	"github.com/masaushi/accessory/cmd/testdata/import_packages/sub1"
 	"github.com/masaushi/accessory/cmd/testdata/import_packages/sub2"
 	"github.com/masaushi/accessory/cmd/testdata/import_packages/sub3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
 	"time"
 )
 
-func (t *Tester) Field1() time.Time {
+// GetField1 returns the Tester's field1.
+func (t *Tester) GetField1() time.Time {
 	if t == nil {
-		return time.Time{}
+		return nil
 	}
+
 	return t.field1
 }
 
@@ -23,10 +28,12 @@ func (t *Tester) SetField1(val time.Time) {
 	t.field1 = val
 }
 
-func (t *Tester) Field2() *time.Time {
+// GetField2 returns the Tester's field2.
+func (t *Tester) GetField2() *time.Time {
 	if t == nil {
 		return nil
 	}
+
 	return t.field2
 }
 
@@ -37,10 +44,12 @@ func (t *Tester) SetField2(val *time.Time) {
 	t.field2 = val
 }
 
-func (t *Tester) Field3() *sub1.SubTester {
+// GetField3 returns the Tester's field3.
+func (t *Tester) GetField3() *sub1.SubTester {
 	if t == nil {
 		return nil
 	}
+
 	return t.field3
 }
 
@@ -51,10 +60,12 @@ func (t *Tester) SetField3(val *sub1.SubTester) {
 	t.field3 = val
 }
 
-func (t *Tester) Field4() *sub2.SubTester {
+// GetField4 returns the Tester's field4.
+func (t *Tester) GetField4() *sub2.SubTester {
 	if t == nil {
 		return nil
 	}
+
 	return t.field4
 }
 
@@ -65,10 +76,12 @@ func (t *Tester) SetField4(val *sub2.SubTester) {
 	t.field4 = val
 }
 
-func (t *Tester) Field5() *sub3.SubTester {
+// GetField5 returns the Tester's field5.
+func (t *Tester) GetField5() *sub3.SubTester {
 	if t == nil {
 		return nil
 	}
+
 	return t.field5
 }
 
@@ -79,10 +92,12 @@ func (t *Tester) SetField5(val *sub3.SubTester) {
 	t.field5 = val
 }
 
-func (t *Tester) Field6() []sub2.SubTester {
+// GetField6 returns the Tester's field6.
+func (t *Tester) GetField6() []sub2.SubTester {
 	if t == nil {
 		return nil
 	}
+
 	return t.field6
 }
 
@@ -93,10 +108,12 @@ func (t *Tester) SetField6(val []sub2.SubTester) {
 	t.field6 = val
 }
 
-func (t *Tester) Field7() []*sub2.SubTester {
+// GetField7 returns the Tester's field7.
+func (t *Tester) GetField7() []*sub2.SubTester {
 	if t == nil {
 		return nil
 	}
+
 	return t.field7
 }
 
@@ -107,3 +124,222 @@ func (t *Tester) SetField7(val []*sub2.SubTester) {
 	t.field7 = val
 }
 
+// ToProto converts Tester to the Protobuf version.
+func (tester *Tester) ToProto() *replaceMe.Tester {
+	if tester == nil {
+		return nil
+	}
+
+	result := &replaceMe.Tester{
+		Field3: tester.field3,
+		Field4: tester.field4,
+		Field5: tester.field5,
+		Field6: tester.field6,
+		Field7: tester.field7,
+	}
+
+	if !tester.field1.IsZero() {
+		result.Field1 = timestamppb.New(tester.field1)
+	}
+
+	if tester.field2 != nil {
+		result.Field2 = timestamppb.New(*tester.field2)
+	}
+
+	return result
+}
+
+// ProtoToTester converts from Protobuf version to the Tester.
+func ProtoToTester(tester *replaceMe.Tester) *Tester {
+	if tester == nil {
+		return nil
+	}
+
+	result := &Tester{
+		field3: tester.Field3,
+		field4: tester.Field4,
+		field5: tester.Field5,
+		field6: tester.Field6,
+		field7: tester.Field7,
+	}
+
+	if tester.Field1 != nil {
+		result.field1 = tester.Field1.AsTime()
+	}
+
+	if tester.Field2 != nil {
+		value := tester.Field2.AsTime()
+		result.field2 = &value
+	}
+
+	return result
+}
+
+// TestersToProto converts a slice of Tester to the Protobuf version.
+func TestersToProto(items []*Tester) []*replaceMe.Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]*replaceMe.Tester, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.ToProto())
+	}
+
+	return result
+}
+
+// ProtoToTesters converts a slice of the Protobuf version to Tester.
+func ProtoToTesters(items []*replaceMe.Tester) []*Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]*Tester, 0, len(items))
+	for _, item := range items {
+		result = append(result, ProtoToTester(item))
+	}
+
+	return result
+}
+
+// TesterMapToProto converts a map of Tester to the Protobuf version.
+func TesterMapToProto[K comparable](items map[K]*Tester) map[K]*replaceMe.Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make(map[K]*replaceMe.Tester, len(items))
+	for key, item := range items {
+		result[key] = item.ToProto()
+	}
+
+	return result
+}
+
+// ProtoToTesterMap converts a map of the Protobuf version to Tester.
+func ProtoToTesterMap[K comparable](items map[K]*replaceMe.Tester) map[K]*Tester {
+	if items == nil {
+		return nil
+	}
+
+	result := make(map[K]*Tester, len(items))
+	for key, item := range items {
+		result[key] = ProtoToTester(item)
+	}
+
+	return result
+}
+
+func TestTester_GetFunctions(t *testing.T) {
+	type want struct {
+		args       *models.Tester
+		wantfield1 time.Time
+		wantfield2 *time.Time
+		wantfield3 *sub1.SubTester
+		wantfield4 *sub2.SubTester
+		wantfield5 *sub3.SubTester
+		wantfield6 []sub2.SubTester
+		wantfield7 []*sub2.SubTester
+		wantProto  *replaceMe.Tester
+	}
+
+	type Context struct {
+		testData *want
+	}
+
+	contextInitiateFunction := func(t *testing.T) *Context {
+		return &Context{}
+	}
+
+	gt.Begin(t,
+		contextInitiateFunction,
+		gt.Run("Get functions return proper value", func(t *testing.T, ctx *Context) {
+			// GET functions
+			gotfield1 := ctx.testData.args.GetField1()
+			assert.Equal(t, ctx.testData.wantfield1, gotfield1)
+
+			gotfield2 := ctx.testData.args.GetField2()
+			assert.Equal(t, ctx.testData.wantfield2, gotfield2)
+
+			gotfield3 := ctx.testData.args.GetField3()
+			assert.Equal(t, ctx.testData.wantfield3, gotfield3)
+
+			gotfield4 := ctx.testData.args.GetField4()
+			assert.Equal(t, ctx.testData.wantfield4, gotfield4)
+
+			gotfield5 := ctx.testData.args.GetField5()
+			assert.Equal(t, ctx.testData.wantfield5, gotfield5)
+
+			gotfield6 := ctx.testData.args.GetField6()
+			assert.Equal(t, ctx.testData.wantfield6, gotfield6)
+
+			gotfield7 := ctx.testData.args.GetField7()
+			assert.Equal(t, ctx.testData.wantfield7, gotfield7)
+
+			// Convert from models to Proto.
+			gotProto := ctx.testData.args.ToProto()
+			assert.Equal(t, ctx.testData.wantProto, gotProto)
+
+			// Then convert from Proto back to model
+			gotModel := models.ProtoToTester(gotProto)
+			assert.Equal(t, ctx.testData.args, gotModel)
+		}).
+			Using("given nil value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args:       nil,
+					wantfield1: nil,
+					wantfield2: nil,
+					wantfield3: nil,
+					wantfield4: nil,
+					wantfield5: nil,
+					wantfield6: nil,
+					wantfield7: nil,
+					wantProto:  nil,
+				}
+			}).
+			Using("given empty value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args:       &models.Tester{},
+					wantfield1: nil,
+					wantfield2: nil,
+					wantfield3: nil,
+					wantfield4: nil,
+					wantfield5: nil,
+					wantfield6: nil,
+					wantfield7: nil,
+					wantProto:  &replaceMe.Tester{},
+				}
+			}).
+			Using("given NON nil value", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					args: models.ProtoToTester(&replaceMe.Tester{
+						Field1: timestamppb.New(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+						Field2: timestamppb.New(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+						Field3: func() *sub1.SubTester { var v sub1.SubTester = sub1.SubTester{}; return &v }(),
+						Field4: func() *sub2.SubTester { var v sub2.SubTester = sub2.SubTester{}; return &v }(),
+						Field5: func() *sub3.SubTester { var v sub3.SubTester = sub3.SubTester{}; return &v }(),
+						Field6: nil,
+						Field7: nil,
+					}),
+					wantfield1: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+					wantfield2: func() *time.Time { var v time.Time = time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC); return &v }(),
+					wantfield3: func() *sub1.SubTester { var v sub1.SubTester = sub1.SubTester{}; return &v }(),
+					wantfield4: func() *sub2.SubTester { var v sub2.SubTester = sub2.SubTester{}; return &v }(),
+					wantfield5: func() *sub3.SubTester { var v sub3.SubTester = sub3.SubTester{}; return &v }(),
+					wantfield6: nil,
+					wantfield7: nil,
+					wantProto: &replaceMe.Tester{
+						Field1: timestamppb.New(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+						Field2: timestamppb.New(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+						Field3: func() *sub1.SubTester { var v sub1.SubTester = sub1.SubTester{}; return &v }(),
+						Field4: func() *sub2.SubTester { var v sub2.SubTester = sub2.SubTester{}; return &v }(),
+						Field5: func() *sub3.SubTester { var v sub3.SubTester = sub3.SubTester{}; return &v }(),
+						Field6: nil,
+						Field7: nil,
+					},
+				}
+			}),
+	)
+}
+
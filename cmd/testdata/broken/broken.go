@@ -0,0 +1,5 @@
+package broken
+
+// Broken doesn't parse, its package is reported while the other ones are generated.
+type Broken struct {
+	name string `accessor:"getter"`
@@ -0,0 +1,33 @@
+// Package moneyconv is imported as .../converter/v2, its name not being the last element of the path.
+package moneyconv
+
+import "github.com/masaushi/accessory/cmd/testdata/well_known/money"
+
+type Money struct {
+	Units int64
+	Nanos int32
+}
+
+func MoneyToProto(m money.Money) *Money {
+	return &Money{Units: m.Units, Nanos: m.Nanos}
+}
+
+func ProtoToMoney(m *Money) money.Money {
+	return money.Money{Units: m.GetUnits(), Nanos: m.GetNanos()}
+}
+
+func (m *Money) GetUnits() int64 {
+	if m == nil {
+		return 0
+	}
+
+	return m.Units
+}
+
+func (m *Money) GetNanos() int32 {
+	if m == nil {
+		return 0
+	}
+
+	return m.Nanos
+}
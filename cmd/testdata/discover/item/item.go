@@ -0,0 +1,9 @@
+package item
+
+// Item is generated with getters only, from the directive.
+//
+//accessory:generate
+type Item struct {
+	name  string
+	price int64
+}
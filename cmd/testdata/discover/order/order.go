@@ -0,0 +1,11 @@
+package order
+
+type Order struct {
+	id     string `accessor:"getter"`
+	amount int64  `accessor:"getter,setter"`
+}
+
+// Note is left out, none of its fields having the accessor tag.
+type Note struct {
+	text string
+}
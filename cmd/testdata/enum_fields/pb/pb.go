@@ -0,0 +1,8 @@
+package pb
+
+type State int32
+
+const (
+	State_STATE_UNSPECIFIED State = 0
+	State_STATE_ACTIVE      State = 1
+)
@@ -0,0 +1,33 @@
+package test
+
+import "github.com/masaushi/accessory/cmd/testdata/enum_fields/pb"
+
+type Tester struct {
+	name  string `accessor:"getter"`
+	state State  `accessor:"getter"`
+}
+
+type State int
+
+const (
+	StateUnspecified State = iota
+	StateActive
+)
+
+func (s State) ToProto() pb.State {
+	switch s {
+	case StateActive:
+		return pb.State_STATE_ACTIVE
+	default:
+		return pb.State_STATE_UNSPECIFIED
+	}
+}
+
+func ProtoToState(s pb.State) State {
+	switch s {
+	case pb.State_STATE_ACTIVE:
+		return StateActive
+	default:
+		return StateUnspecified
+	}
+}
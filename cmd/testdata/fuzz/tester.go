@@ -0,0 +1,16 @@
+package test
+
+type Tester struct {
+	id     string   `accessor:"getter"`
+	count  int32    `accessor:"getter"`
+	total  uint64   `accessor:"getter"`
+	level  int8     `accessor:"getter"`
+	port   uint16   `accessor:"getter"`
+	active bool     `accessor:"getter"`
+	ratio  float64  `accessor:"getter"`
+	code   Code     `accessor:"getter"`
+	tags   []string `accessor:"getter"`
+	model  string   `accessor:"getter"`
+}
+
+type Code string
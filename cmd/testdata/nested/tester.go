@@ -0,0 +1,18 @@
+package test
+
+type Tester struct {
+	shipping *Address             `accessor:"getter"`
+	billing  Address              `accessor:"getter"`
+	items    []*LineItem          `accessor:"getter"`
+	byCode   map[string]*LineItem `accessor:"getter"`
+	tags     []string             `accessor:"getter"`
+}
+
+type Address struct {
+	city string `accessor:"getter"`
+}
+
+type LineItem struct {
+	sku      string `accessor:"getter"`
+	quantity int32  `accessor:"getter"`
+}
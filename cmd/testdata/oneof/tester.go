@@ -0,0 +1,30 @@
+package test
+
+type Tester struct {
+	id      string   `accessor:"getter"`
+	email   *string  `accessor:"getter,oneof=contact"`
+	phone   *Phone   `accessor:"getter,oneof=contact"`
+	payment Payment  `accessor:"getter"`
+	channel *Channel `accessor:"getter,oneof=contact"`
+}
+
+type Phone struct {
+	number string `accessor:"getter"`
+}
+
+type Channel string
+
+// Payment is the payment oneof, every implementation is a branch.
+type Payment interface {
+	isPayment()
+}
+
+type PaymentCard struct {
+	last4 string `accessor:"getter"`
+}
+
+func (*PaymentCard) isPayment() {}
+
+type PaymentVoucher string
+
+func (PaymentVoucher) isPayment() {}
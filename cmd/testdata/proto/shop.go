@@ -0,0 +1,10 @@
+package shop
+
+import "time"
+
+type Order struct {
+	id        string    `accessor:"getter"`
+	note      string    `accessor:"getter"`
+	quantity  int32     `accessor:"getter"`
+	createdAt time.Time `accessor:"getter"`
+}
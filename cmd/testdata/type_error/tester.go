@@ -0,0 +1,8 @@
+package test
+
+// Tester type checks with an undefined name, its package is reported while its accessors are generated.
+type Tester struct {
+	name string `accessor:"getter"`
+}
+
+var _ = undefined
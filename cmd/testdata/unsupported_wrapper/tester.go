@@ -0,0 +1,21 @@
+package test
+
+import "time"
+
+// The google.protobuf wrappers only hold scalars and bytes, none of these fields has one.
+
+type TimeTester struct {
+	deletedAt *time.Time `accessor:"getter,wrapper"`
+}
+
+type SliceTester struct {
+	tags []string `accessor:"getter,wrapper"`
+}
+
+type StructTester struct {
+	address Address `accessor:"getter,wrapper"`
+}
+
+type Address struct {
+	city string `accessor:"getter"`
+}
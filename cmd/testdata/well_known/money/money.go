@@ -0,0 +1,6 @@
+package money
+
+type Money struct {
+	Units int64
+	Nanos int32
+}
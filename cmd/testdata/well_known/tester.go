@@ -0,0 +1,18 @@
+package test
+
+import (
+	"time"
+
+	"github.com/masaushi/accessory/cmd/testdata/well_known/money"
+)
+
+type Tester struct {
+	createdAt time.Time     `accessor:"getter"`
+	deletedAt *time.Time    `accessor:"getter"`
+	timeout   time.Duration `accessor:"getter"`
+	nickname  *string       `accessor:"getter"`
+	retries   *int64        `accessor:"getter"`
+	avatar    []byte        `accessor:"getter"`
+	thumbnail []byte        `accessor:"getter,wrapper"`
+	price     money.Money   `accessor:"getter"`
+}
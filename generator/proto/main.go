@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/cmd"
+)
+
+func main() {
+	os.Exit(cmd.ExecuteProto(afero.NewOsFs(), os.Args))
+}
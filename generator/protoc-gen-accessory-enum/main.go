@@ -0,0 +1,17 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("protoc-gen-accessory-enum: ")
+
+	if err := enum.RunPlugin(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
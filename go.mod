@@ -4,16 +4,17 @@ go 1.21
 
 require (
 	github.com/bradleyjkemp/cupaloy/v2 v2.8.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/afero v1.9.5
 	github.com/stretchr/testify v1.7.0
 	go.mongodb.org/mongo-driver v1.12.1
 	golang.org/x/text v0.13.0
 	golang.org/x/tools v0.13.0
+	google.golang.org/protobuf v1.31.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/mod v0.12.0 // indirect
 	golang.org/x/sys v0.12.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
@@ -0,0 +1,769 @@
+package accessor
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+var (
+	firstCapMatcher   = regexp.MustCompile("(.)([A-Z][a-z]+)")
+	articleCapMatcher = regexp.MustCompile("([a-z0-9])([A-Z])")
+)
+
+// oneof is a proto oneof of the struct, represented either by an interface field whose
+// implementations in the package are the branches, or by pointer fields tagged accessor:"oneof=kind".
+type oneof struct {
+	// ProtoField is the field protoc-gen-go generates for the oneof: Contact.
+	ProtoField string
+	// Field is the interface field, empty for pointer fields.
+	Field    string
+	Branches []*oneofBranch
+}
+
+type oneofBranch struct {
+	// Name is the oneof field in proto Go: Email gives Order_Email{Email: ...}.
+	Name string
+	// Field is the pointer field of the branch, empty for an interface implementation.
+	Field string
+	// Type is the type of the value, the element type of a pointer field or the implementation.
+	Type types.Type
+}
+
+type conversionGenParameters struct {
+	Struct string
+	Plural string
+}
+
+// generateConversion fills in the bodies of ToProto and ProtoTo<Struct>, and the round trip tests of the oneofs.
+func (g *generator) generateConversion(pkg *Package, st *Struct, testParameters *testGenParameters) error {
+	oneofs, err := g.parseOneofs(pkg, st)
+	if err != nil {
+		return err
+	}
+
+	oneofFields := make(map[string]bool)
+	for _, o := range oneofs {
+		if o.Field != "" {
+			oneofFields[o.Field] = true
+		}
+
+		for _, branch := range o.Branches {
+			if branch.Field != "" {
+				oneofFields[branch.Field] = true
+			}
+		}
+	}
+
+	receiver := testParameters.Receiver
+	toProto := fmt.Sprintf("result := &replaceMe.%s{\n", st.Name)
+	protoTo := fmt.Sprintf("result := &%s{\n", st.Name)
+
+	// Fields going through a converter are set after the literal, as they may stay unset.
+	var toProtoStatements, protoToStatements string
+
+	fuzzFields := make([]*fuzzField, 0)
+
+	for i, field := range st.Fields {
+		if oneofFields[field.Name] || g.skipConversion(field) {
+			continue
+		}
+
+		protoField := protoFieldName(field.Name)
+
+		conversion, err := g.convertField(pkg, field, receiver, protoField)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", st.Name, field.Name, err)
+		}
+
+		// The NON nil test case sets every field but the oneofs, tested on their own.
+		sample, err := g.fieldSampleOf(pkg, field, i+1)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", st.Name, field.Name, err)
+		}
+
+		testParameters.NonNilProto = testParameters.NonNilProto + fmt.Sprintf("%s: %s,\n", protoField, sample.Proto)
+		if field.Tag.Getter != nil {
+			testParameters.NonNilTestData = testParameters.NonNilTestData + fmt.Sprintf("want%s: %s,\n", field.Name, sample.Model)
+		}
+
+		if g.fuzz {
+			if fuzz := g.newFuzzField(pkg, field, protoField, i+1); fuzz != nil {
+				fuzzFields = append(fuzzFields, fuzz)
+			}
+		}
+
+		if conversion.ToProtoStatement != "" {
+			toProtoStatements = toProtoStatements + "\n" + conversion.ToProtoStatement
+		} else {
+			toProto = toProto + fmt.Sprintf("%s: %s,\n", protoField, conversion.ToProto)
+		}
+
+		if conversion.EmptyProto != "" {
+			testParameters.EmptyProto = testParameters.EmptyProto + fmt.Sprintf("%s: %s,\n", protoField, conversion.EmptyProto)
+		}
+
+		if conversion.ProtoToStatement != "" {
+			protoToStatements = protoToStatements + "\n" + conversion.ProtoToStatement
+		} else {
+			protoTo = protoTo + fmt.Sprintf("%s: %s,\n", field.Name, conversion.ProtoTo)
+		}
+	}
+
+	toProto = toProto + "}\n" + toProtoStatements
+	protoTo = protoTo + "}\n" + protoToStatements
+
+	for _, o := range oneofs {
+		toProto = toProto + "\n" + g.oneofToProto(pkg, st, receiver, o)
+		protoTo = protoTo + "\n" + g.oneofProtoTo(pkg, st, receiver, o)
+
+		test, err := g.generateOneofTest(pkg, st, testParameters, o)
+		if err != nil {
+			return err
+		}
+
+		testParameters.OneofTests = testParameters.OneofTests + "\n" + test
+	}
+
+	fuzzTest, err := g.generateFuzzTest(st, testParameters, fuzzFields)
+	if err != nil {
+		return err
+	}
+
+	testParameters.FuzzTest = fuzzTest
+	testParameters.NonNilTestData = strings.TrimSuffix(testParameters.NonNilTestData, "\n")
+	testParameters.ToProtoBody = toProto + "\nreturn result"
+	testParameters.ProtoToBody = protoTo + "\nreturn result"
+
+	helpers, err := g.generateModelHelpers(st)
+	if err != nil {
+		return err
+	}
+
+	testParameters.ModelHelpers = helpers
+
+	return nil
+}
+
+// fieldConversion is the mapping of a field that isn't part of a oneof: its values in the result literals,
+// or the statements setting it after them when it may stay unset.
+type fieldConversion struct {
+	ToProto          string
+	ProtoTo          string
+	ToProtoStatement string
+	ProtoToStatement string
+	// EmptyProto is the proto value of the field of an empty struct, when it isn't the zero value.
+	EmptyProto string
+}
+
+// convertField maps the field with a converter, through the ToProto and ProtoTo<Type> of the models
+// and enums it holds, or assigns it as it is.
+func (g *generator) convertField(pkg *Package, field *Field, receiver, protoField string) (*fieldConversion, error) {
+	model := receiver + "." + field.Name
+	proto := receiver + "." + protoField
+
+	c, err := g.fieldConverterOf(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if c != nil {
+		toProto, err := c.toProto(model, "result."+protoField)
+		if err != nil {
+			return nil, err
+		}
+
+		protoTo, err := c.fromProto("result."+field.Name, proto)
+		if err != nil {
+			return nil, err
+		}
+
+		g.imports = append(g.imports, c.Imports...)
+
+		return &fieldConversion{ToProtoStatement: toProto, ProtoToStatement: protoTo}, nil
+	}
+
+	switch t := field.Type.(type) {
+	case *types.Pointer:
+		if named := g.model(pkg, t.Elem()); named != nil {
+			return &fieldConversion{
+				ToProto: model + ".ToProto()",
+				ProtoTo: fmt.Sprintf("%s(%s)", g.modelFunc(pkg, named, "ProtoTo"+named.Obj().Name()), proto),
+			}, nil
+		}
+	case *types.Named:
+		if named := g.model(pkg, t); named != nil {
+			// ProtoTo returns a pointer, nil for an unset message.
+			return &fieldConversion{
+				ToProto:    model + ".ToProto()",
+				EmptyProto: fmt.Sprintf("&replaceMe.%s{}", named.Obj().Name()),
+				ProtoToStatement: fmt.Sprintf("if value := %s(%s); value != nil {\nresult.%s = *value\n}\n",
+					g.modelFunc(pkg, named, "ProtoTo"+named.Obj().Name()), proto, field.Name),
+			}, nil
+		}
+
+		if g.hasEnumConverters(t) {
+			return &fieldConversion{
+				ToProto: model + ".ToProto()",
+				ProtoTo: fmt.Sprintf("%s(%s)", g.modelFunc(pkg, t, "ProtoTo"+t.Obj().Name()), proto),
+			}, nil
+		}
+
+		if g.isEnum(pkg, t) {
+			return nil, fmt.Errorf("enum %[1]s has no ToProto method and ProtoTo%[1]s function, "+
+				"generate them with accessory enum, or with its -reverse mode from the Go constants", t.Obj().Name())
+		}
+	case *types.Slice:
+		if named := g.modelPointer(pkg, t.Elem()); named != nil {
+			return &fieldConversion{
+				ToProto: fmt.Sprintf("%s(%s)", g.modelFunc(pkg, named, pluralize(named.Obj().Name())+"ToProto"), model),
+				ProtoTo: fmt.Sprintf("%s(%s)", g.modelFunc(pkg, named, "ProtoTo"+pluralize(named.Obj().Name())), proto),
+			}, nil
+		}
+	case *types.Map:
+		if named := g.modelPointer(pkg, t.Elem()); named != nil {
+			return &fieldConversion{
+				ToProto: fmt.Sprintf("%s(%s)", g.modelFunc(pkg, named, named.Obj().Name()+"MapToProto"), model),
+				ProtoTo: fmt.Sprintf("%s(%s)", g.modelFunc(pkg, named, "ProtoTo"+named.Obj().Name()+"Map"), proto),
+			}, nil
+		}
+	}
+
+	return &fieldConversion{ToProto: model, ProtoTo: proto}, nil
+}
+
+// model returns the named struct if t is a model with ToProto and ProtoTo<Struct>: a struct of the package
+// accessory generates them for, or a struct of another package that has them.
+func (g *generator) model(pkg *Package, t types.Type) *types.Named {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	if named.Obj().Pkg() == pkg.Types {
+		return named
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, named.Obj().Pkg(), "ToProto")
+	if _, ok := obj.(*types.Func); !ok {
+		return nil
+	}
+
+	return named
+}
+
+// hasEnumConverters reports whether the named type has the conversions generated by accessory enum:
+// a ToProto method, and a ProtoTo<Type> function in its package.
+func (g *generator) hasEnumConverters(named *types.Named) bool {
+	if _, ok := named.Underlying().(*types.Basic); !ok {
+		return false
+	}
+
+	method, _, _ := types.LookupFieldOrMethod(named, false, named.Obj().Pkg(), "ToProto")
+	if _, ok := method.(*types.Func); !ok {
+		return false
+	}
+
+	_, ok := named.Obj().Pkg().Scope().Lookup("ProtoTo" + named.Obj().Name()).(*types.Func)
+
+	return ok
+}
+
+// isEnum reports whether the named type is an integer type of the package with constants,
+// which is an enum in proto.
+func (g *generator) isEnum(pkg *Package, named *types.Named) bool {
+	if named.Obj().Pkg() != pkg.Types {
+		return false
+	}
+
+	for _, enum := range pkg.Enums {
+		if enum.Name == named.Obj().Name() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// modelPointer returns the named struct if t is a pointer to a model, the element type of the helpers.
+func (g *generator) modelPointer(pkg *Package, t types.Type) *types.Named {
+	pointer, ok := t.(*types.Pointer)
+	if !ok {
+		return nil
+	}
+
+	return g.model(pkg, pointer.Elem())
+}
+
+// modelFunc qualifies the name of a conversion function of the model if it is declared in another package.
+func (g *generator) modelFunc(pkg *Package, named *types.Named, name string) string {
+	if named.Obj().Pkg() != pkg.Types {
+		return named.Obj().Pkg().Name() + "." + name
+	}
+
+	return name
+}
+
+// generateModelHelpers generates the conversions of the slices and maps of the struct,
+// the structs holding them call these instead of looping.
+func (g *generator) generateModelHelpers(st *Struct) (string, error) {
+	var helpersTemplate = `
+	// {{.Plural}}ToProto converts a slice of {{.Struct}} to the Protobuf version.
+	func {{.Plural}}ToProto(items []*{{.Struct}}) []*replaceMe.{{.Struct}} {
+		if items == nil {
+			return nil
+		}
+
+		result := make([]*replaceMe.{{.Struct}}, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.ToProto())
+		}
+
+		return result
+	}
+
+	// ProtoTo{{.Plural}} converts a slice of the Protobuf version to {{.Struct}}.
+	func ProtoTo{{.Plural}}(items []*replaceMe.{{.Struct}}) []*{{.Struct}} {
+		if items == nil {
+			return nil
+		}
+
+		result := make([]*{{.Struct}}, 0, len(items))
+		for _, item := range items {
+			result = append(result, ProtoTo{{.Struct}}(item))
+		}
+
+		return result
+	}
+
+	// {{.Struct}}MapToProto converts a map of {{.Struct}} to the Protobuf version.
+	func {{.Struct}}MapToProto[K comparable](items map[K]*{{.Struct}}) map[K]*replaceMe.{{.Struct}} {
+		if items == nil {
+			return nil
+		}
+
+		result := make(map[K]*replaceMe.{{.Struct}}, len(items))
+		for key, item := range items {
+			result[key] = item.ToProto()
+		}
+
+		return result
+	}
+
+	// ProtoTo{{.Struct}}Map converts a map of the Protobuf version to {{.Struct}}.
+	func ProtoTo{{.Struct}}Map[K comparable](items map[K]*replaceMe.{{.Struct}}) map[K]*{{.Struct}} {
+		if items == nil {
+			return nil
+		}
+
+		result := make(map[K]*{{.Struct}}, len(items))
+		for key, item := range items {
+			result[key] = ProtoTo{{.Struct}}(item)
+		}
+
+		return result
+	}`
+
+	t := template.Must(template.New("modelHelpers").Parse(helpersTemplate))
+	buf := new(bytes.Buffer)
+
+	if err := t.Execute(buf, &conversionGenParameters{
+		Struct: st.Name,
+		Plural: pluralize(st.Name),
+	}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// skipConversion reports whether the field has no counterpart in proto: the lock and the sync types.
+// They are left out of the accessors as well.
+func (g *generator) skipConversion(field *Field) bool {
+	if g.lock != "" && field.Name == g.lock {
+		return true
+	}
+
+	named, ok := field.Type.(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "sync"
+}
+
+// parseOneofs finds the oneofs of the struct, in the order of their first field.
+func (g *generator) parseOneofs(pkg *Package, st *Struct) ([]*oneof, error) {
+	oneofs := make([]*oneof, 0)
+	byName := make(map[string]*oneof)
+
+	for _, field := range st.Fields {
+		if field.Tag != nil && field.Tag.Oneof != "" {
+			pointer, ok := field.Type.(*types.Pointer)
+			if !ok {
+				return nil, fmt.Errorf("%s.%s: the fields of oneof %s must be pointers", st.Name, field.Name, field.Tag.Oneof)
+			}
+
+			o, ok := byName[field.Tag.Oneof]
+			if !ok {
+				o = &oneof{ProtoField: protoFieldName(field.Tag.Oneof)}
+				byName[field.Tag.Oneof] = o
+				oneofs = append(oneofs, o)
+			}
+
+			o.Branches = append(o.Branches, &oneofBranch{
+				Name:  protoFieldName(field.Name),
+				Field: field.Name,
+				Type:  pointer.Elem(),
+			})
+
+			continue
+		}
+
+		branches := g.interfaceBranches(pkg, field.Type)
+		if len(branches) == 0 {
+			continue
+		}
+
+		oneofs = append(oneofs, &oneof{
+			ProtoField: protoFieldName(field.Name),
+			Field:      field.Name,
+			Branches:   branches,
+		})
+	}
+
+	return oneofs, nil
+}
+
+// interfaceBranches returns the types of the package implementing t, in declaration order,
+// if t is a non-empty interface declared in the package.
+func (g *generator) interfaceBranches(pkg *Package, t types.Type) []*oneofBranch {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() != pkg.Types {
+		return nil
+	}
+
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok || iface.NumMethods() == 0 {
+		return nil
+	}
+
+	scope := pkg.Types.Scope()
+	objects := make([]types.Object, 0)
+
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() || types.IsInterface(obj.Type()) {
+			continue
+		}
+
+		objects = append(objects, obj)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Pos() < objects[j].Pos()
+	})
+
+	branches := make([]*oneofBranch, 0)
+
+	for _, obj := range objects {
+		var branchType types.Type
+
+		switch {
+		case types.Implements(obj.Type(), iface):
+			branchType = obj.Type()
+		case types.Implements(types.NewPointer(obj.Type()), iface):
+			branchType = types.NewPointer(obj.Type())
+		default:
+			continue
+		}
+
+		// ContactEmail implementing Contact is the email branch.
+		name := strings.TrimPrefix(obj.Name(), named.Obj().Name())
+		if name == "" || strings.ToUpper(name[:1]) != name[:1] {
+			name = obj.Name()
+		}
+
+		branches = append(branches, &oneofBranch{
+			Name: protoFieldName(name),
+			Type: branchType,
+		})
+	}
+
+	return branches
+}
+
+// oneofToProto sets the wrapper of the branch in use.
+func (g *generator) oneofToProto(pkg *Package, st *Struct, receiver string, o *oneof) string {
+	result := ""
+
+	if o.Field != "" {
+		result = fmt.Sprintf("switch branch := %s.%s.(type) {\n", receiver, o.Field)
+		for _, branch := range o.Branches {
+			result = result + fmt.Sprintf("case %s:\nresult.%s = &replaceMe.%s_%s{%s: %s}\n",
+				g.typeName(pkg.Types, branch.Type), o.ProtoField, st.Name, branch.Name, branch.Name,
+				g.valueToProto("branch", branch.Type))
+		}
+
+		return result + "}\n"
+	}
+
+	result = "switch {\n"
+	for _, branch := range o.Branches {
+		value := receiver + "." + branch.Field
+		if !isStruct(branch.Type) {
+			value = "*" + value
+		}
+
+		result = result + fmt.Sprintf("case %s.%s != nil:\nresult.%s = &replaceMe.%s_%s{%s: %s}\n",
+			receiver, branch.Field, o.ProtoField, st.Name, branch.Name, branch.Name,
+			g.valueToProto(value, branch.Type))
+	}
+
+	return result + "}\n"
+}
+
+// oneofProtoTo sets the field of the branch in use.
+func (g *generator) oneofProtoTo(pkg *Package, st *Struct, receiver string, o *oneof) string {
+	result := fmt.Sprintf("switch branch := %s.%s.(type) {\n", receiver, o.ProtoField)
+
+	for _, branch := range o.Branches {
+		result = result + fmt.Sprintf("case *replaceMe.%s_%s:\n", st.Name, branch.Name)
+		value := "branch." + branch.Name
+
+		switch {
+		case o.Field == "" && isStruct(branch.Type):
+			result = result + fmt.Sprintf("result.%s = %s\n", branch.Field, g.valueFromProto(pkg, value, types.NewPointer(branch.Type)))
+		case o.Field == "":
+			result = result + fmt.Sprintf("value := %s\nresult.%s = &value\n", g.valueFromProto(pkg, value, branch.Type), branch.Field)
+		case isStruct(branch.Type) && !isPointer(branch.Type):
+			// A struct implementing the interface by value, ProtoTo returns a pointer.
+			result = result + fmt.Sprintf("if value := %s; value != nil {\nresult.%s = *value\n}\n",
+				g.valueFromProto(pkg, value, types.NewPointer(branch.Type)), o.Field)
+		case isStruct(branch.Type):
+			// ProtoTo returns nil for a branch without its message, which would be a non nil interface.
+			result = result + fmt.Sprintf("if value := %s; value != nil {\nresult.%s = value\n}\n",
+				g.valueFromProto(pkg, value, branch.Type), o.Field)
+		default:
+			result = result + fmt.Sprintf("result.%s = %s\n", o.Field, g.valueFromProto(pkg, value, branch.Type))
+		}
+	}
+
+	return result + "}\n"
+}
+
+// valueToProto converts the value of a branch: structs have their own ToProto, named scalars are converted
+// to the scalar protoc-gen-go uses.
+func (g *generator) valueToProto(value string, t types.Type) string {
+	if isStruct(t) {
+		return value + ".ToProto()"
+	}
+
+	if named, ok := t.(*types.Named); ok {
+		if basic, ok := named.Underlying().(*types.Basic); ok {
+			return fmt.Sprintf("%s(%s)", basic.Name(), value)
+		}
+	}
+
+	return value
+}
+
+// valueFromProto converts the proto value of a branch back, pointers to structs go through their ProtoTo.
+func (g *generator) valueFromProto(pkg *Package, value string, t types.Type) string {
+	if pointer, ok := t.(*types.Pointer); ok && isStruct(pointer.Elem()) {
+		named := pointer.Elem().(*types.Named)
+
+		return fmt.Sprintf("%s(%s)", g.modelFunc(pkg, named, "ProtoTo"+named.Obj().Name()), value)
+	}
+
+	if named, ok := t.(*types.Named); ok {
+		if _, ok := named.Underlying().(*types.Basic); ok {
+			return fmt.Sprintf("%s(%s)", g.typeName(pkg.Types, t), value)
+		}
+	}
+
+	return value
+}
+
+// generateOneofTest generates a test converting every branch of the oneof from proto and back.
+func (g *generator) generateOneofTest(pkg *Package, st *Struct, testParameters *testGenParameters, o *oneof) (string, error) {
+	cases := []*testgen.Case{
+		{
+			Name: "given no branch",
+			Data: fmt.Sprintf("proto: &replaceMe.%s{},", st.Name),
+		},
+	}
+
+	for _, branch := range o.Branches {
+		cases = append(cases, &testgen.Case{
+			Name: fmt.Sprintf("given the %s branch", branch.Name),
+			Data: fmt.Sprintf("proto: &replaceMe.%s{%s: &replaceMe.%s_%s{%s: %s}},",
+				st.Name, o.ProtoField, st.Name, branch.Name, branch.Name, g.sampleProtoValue(branch)),
+		})
+	}
+
+	data := testgen.Data(g.testStyle)
+
+	result, err := testgen.Render(g.testStyle, &testgen.Test{
+		Name:        fmt.Sprintf("%s_%sOneof", st.Name, o.ProtoField),
+		Description: fmt.Sprintf("Every branch of %s survives the round trip", o.ProtoField),
+		Want:        fmt.Sprintf("proto *replaceMe.%s", st.Name),
+		Cases:       cases,
+		Body: fmt.Sprintf("gotModel := %s.ProtoTo%s(%s.proto)\ngotProto := gotModel.ToProto()\n%s",
+			testParameters.Package, st.Name, data, testgen.Equal(g.testStyle, data+".proto", "gotProto")),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The message branches can be set without their message, which converts to no branch at all.
+	unsetCases := make([]*testgen.Case, 0)
+	for _, branch := range o.Branches {
+		if !isStruct(branch.Type) {
+			continue
+		}
+
+		unsetCases = append(unsetCases, &testgen.Case{
+			Name: fmt.Sprintf("given the %s branch without its message", branch.Name),
+			Data: fmt.Sprintf("proto: &replaceMe.%s{%s: &replaceMe.%s_%s{}},", st.Name, o.ProtoField, st.Name, branch.Name),
+		})
+	}
+
+	if len(unsetCases) == 0 {
+		return result, nil
+	}
+
+	unset, err := testgen.Render(g.testStyle, &testgen.Test{
+		Name:        fmt.Sprintf("%s_%sOneofUnsetMessage", st.Name, o.ProtoField),
+		Description: fmt.Sprintf("A branch of %s without its message converts to no branch", o.ProtoField),
+		Want:        fmt.Sprintf("proto *replaceMe.%s", st.Name),
+		Cases:       unsetCases,
+		Body: fmt.Sprintf("gotModel := %s.ProtoTo%s(%s.proto)\ngotProto := gotModel.ToProto()\n%s",
+			testParameters.Package, st.Name, data, testgen.Equal(g.testStyle, fmt.Sprintf("&replaceMe.%s{}", st.Name), "gotProto")),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result + "\n" + unset, nil
+}
+
+// sampleProtoValue returns a value of the proto type of the branch, other than the zero value
+// so the branch is set after the round trip.
+func (g *generator) sampleProtoValue(branch *oneofBranch) string {
+	t := branch.Type
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	if isStruct(t) {
+		return fmt.Sprintf("&replaceMe.%s{}", t.(*types.Named).Obj().Name())
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsString != 0:
+			return fmt.Sprintf("%q", snakeCase(branch.Name))
+		case info&types.IsBoolean != 0:
+			return "true"
+		case info&types.IsNumeric != 0:
+			return "1"
+		}
+	case *types.Slice:
+		if basic, ok := u.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return fmt.Sprintf("[]byte(%q)", snakeCase(branch.Name))
+		}
+	}
+
+	return "nil"
+}
+
+// isStruct reports whether t is a named struct, converted with its own ToProto and ProtoTo.
+func isStruct(t types.Type) bool {
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	_, ok = named.Underlying().(*types.Struct)
+
+	return ok
+}
+
+func isPointer(t types.Type) bool {
+	_, ok := t.(*types.Pointer)
+
+	return ok
+}
+
+// pluralize is the plural of a Go name: LineItem => LineItems, Category => Categories, Box => Boxes.
+func pluralize(name string) string {
+	lower := strings.ToLower(name)
+
+	switch {
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsAny(lower[len(lower)-2:len(lower)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
+// snakeCase converts a Go name to snake_case: TestStruct => test_struct.
+func snakeCase(name string) string {
+	name = firstCapMatcher.ReplaceAllString(name, "${1}_${2}")
+	name = articleCapMatcher.ReplaceAllString(name, "${1}_${2}")
+
+	return strings.ToLower(name)
+}
+
+// protoFieldName is the name protoc-gen-go gives to the proto field of a model field:
+// deliveryID is delivery_id in proto and DeliveryId in Go.
+func protoFieldName(name string) string {
+	snake := snakeCase(name)
+
+	var result strings.Builder
+	for i := 0; i < len(snake); i++ {
+		c := snake[i]
+
+		switch {
+		case c == '_' && i == 0:
+			result.WriteByte('X')
+		case c == '_' && i+1 < len(snake) && isASCIILower(snake[i+1]):
+			// The next letter is capitalized instead.
+		case c >= '0' && c <= '9':
+			result.WriteByte(c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+
+			result.WriteByte(c)
+
+			for ; i+1 < len(snake) && isASCIILower(snake[i+1]); i++ {
+				result.WriteByte(snake[i+1])
+			}
+		}
+	}
+
+	return result.String()
+}
+
+func isASCIILower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
@@ -0,0 +1,388 @@
+package accessor
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Samples of the well-known types for the generated tests.
+const (
+	sampleTime     = "time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)"
+	sampleDuration = "90 * time.Second"
+)
+
+const (
+	timestamppbImport = "google.golang.org/protobuf/types/known/timestamppb"
+	durationpbImport  = "google.golang.org/protobuf/types/known/durationpb"
+	wrapperspbImport  = "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// converter converts a model type to its proto counterpart and back. The conversions are templates
+// of statements executed with the model and proto expressions, so zero values can be left unset.
+type converter struct {
+	ToProto   string
+	FromProto string
+	// Sample is the model value used in the generated tests, the pointed value for pointers.
+	// The sample of the type is used when it is empty.
+	Sample string
+	// ProtoSample is the template of the proto value of the sample, given the Model value
+	// and the Elem value, the pointed one for pointers.
+	ProtoSample string
+	// Imports are the import paths, or the name and the path separated by a space for the aliased imports.
+	Imports []string
+}
+
+// converterFuncs is a converter registered with the Converter option.
+type converterFuncs struct {
+	Model     string
+	ToProto   string
+	FromProto string
+}
+
+type converterGenParameters struct {
+	Model string
+	Proto string
+	Elem  string
+}
+
+// wellKnownConverters are the built-in conversions, keyed by the model type with the full package path.
+// The zero time and duration are an unset message, like the model generated from a proto file.
+var wellKnownConverters = map[string]*converter{
+	"time.Time": {
+		ToProto: `if !{{.Model}}.IsZero() {
+			{{.Proto}} = timestamppb.New({{.Model}})
+		}`,
+		FromProto: `if {{.Proto}} != nil {
+			{{.Model}} = {{.Proto}}.AsTime()
+		}`,
+		Sample:      sampleTime,
+		ProtoSample: "timestamppb.New({{.Elem}})",
+		Imports:     []string{timestamppbImport},
+	},
+	"*time.Time": {
+		ToProto: `if {{.Model}} != nil {
+			{{.Proto}} = timestamppb.New(*{{.Model}})
+		}`,
+		FromProto: `if {{.Proto}} != nil {
+			value := {{.Proto}}.AsTime()
+			{{.Model}} = &value
+		}`,
+		Sample:      sampleTime,
+		ProtoSample: "timestamppb.New({{.Elem}})",
+		Imports:     []string{timestamppbImport},
+	},
+	"time.Duration": {
+		ToProto: `if {{.Model}} != 0 {
+			{{.Proto}} = durationpb.New({{.Model}})
+		}`,
+		FromProto: `if {{.Proto}} != nil {
+			{{.Model}} = {{.Proto}}.AsDuration()
+		}`,
+		Sample:      sampleDuration,
+		ProtoSample: "durationpb.New({{.Elem}})",
+		Imports:     []string{durationpbImport},
+	},
+	"*time.Duration": {
+		ToProto: `if {{.Model}} != nil {
+			{{.Proto}} = durationpb.New(*{{.Model}})
+		}`,
+		FromProto: `if {{.Proto}} != nil {
+			value := {{.Proto}}.AsDuration()
+			{{.Model}} = &value
+		}`,
+		Sample:      sampleDuration,
+		ProtoSample: "durationpb.New({{.Elem}})",
+		Imports:     []string{durationpbImport},
+	},
+}
+
+// wrapperConverters convert the pointers to scalars to the google.protobuf wrappers,
+// used instead of proto3 optional fields with the Wrappers option.
+var wrapperConverters = map[string]*converter{
+	"*float64": wrapperConverter("Double"),
+	"*float32": wrapperConverter("Float"),
+	"*int64":   wrapperConverter("Int64"),
+	"*uint64":  wrapperConverter("UInt64"),
+	"*int32":   wrapperConverter("Int32"),
+	"*uint32":  wrapperConverter("UInt32"),
+	"*bool":    wrapperConverter("Bool"),
+	"*string":  wrapperConverter("String"),
+}
+
+// bytesWrapperConverter converts a []byte field to google.protobuf.BytesValue. Unlike the pointers,
+// a nil slice can't be told apart from an empty one in proto3, so the fields opt in with the wrapper tag option.
+var bytesWrapperConverter = &converter{
+	ToProto: `if {{.Model}} != nil {
+			{{.Proto}} = wrapperspb.Bytes({{.Model}})
+		}`,
+	FromProto: `if {{.Proto}} != nil {
+			{{.Model}} = {{.Proto}}.GetValue()
+		}`,
+	ProtoSample: "wrapperspb.Bytes({{.Elem}})",
+	Imports:     []string{wrapperspbImport},
+}
+
+func wrapperConverter(name string) *converter {
+	return &converter{
+		ToProto: fmt.Sprintf(`if {{.Model}} != nil {
+			{{.Proto}} = wrapperspb.%s(*{{.Model}})
+		}`, name),
+		FromProto: `if {{.Proto}} != nil {
+			value := {{.Proto}}.GetValue()
+			{{.Model}} = &value
+		}`,
+		ProtoSample: fmt.Sprintf("wrapperspb.%s({{.Elem}})", name),
+		Imports:     []string{wrapperspbImport},
+	}
+}
+
+// newFuncConverter converts with the functions of a user registered converter, given with their import path:
+// github.com/acme/money.DecimalToProto, or with the name to import the package with: money=github.com/acme/money/v2.DecimalToProto.
+// The functions handle nil themselves. names are the package names found for the import paths.
+func newFuncConverter(toProto, fromProto string, names map[string]string) (*converter, error) {
+	toProtoImport, toProtoFunc, err := splitQualifiedFunc(toProto, names)
+	if err != nil {
+		return nil, err
+	}
+
+	fromProtoImport, fromProtoFunc, err := splitQualifiedFunc(fromProto, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return &converter{
+		ToProto:     fmt.Sprintf("{{.Proto}} = %s({{.Model}})", toProtoFunc),
+		FromProto:   fmt.Sprintf("{{.Model}} = %s({{.Proto}})", fromProtoFunc),
+		ProtoSample: fmt.Sprintf("%s({{.Model}})", toProtoFunc),
+		Imports:     []string{toProtoImport, fromProtoImport},
+	}, nil
+}
+
+// splitQualifiedFunc splits github.com/acme/money.DecimalToProto into the import and the call money.DecimalToProto.
+// The package is called by the name given before "=", else by the one found in names, else by a name guessed
+// from the path. The import is aliased when the name isn't the last element of the path.
+func splitQualifiedFunc(qualified string, names map[string]string) (imp, call string, err error) {
+	name, importPath, funcName, err := parseQualifiedFunc(qualified)
+	if err != nil {
+		return "", "", err
+	}
+
+	if name == "" {
+		name = names[importPath]
+	}
+
+	if name == "" {
+		name = guessPackageName(importPath)
+	}
+
+	imp = importPath
+	if name != path.Base(importPath) {
+		imp = name + " " + importPath
+	}
+
+	return imp, name + "." + funcName, nil
+}
+
+// parseQualifiedFunc parses [name=]<import path>.<func>, name being empty when not given.
+func parseQualifiedFunc(qualified string) (name, importPath, funcName string, err error) {
+	if before, after, ok := strings.Cut(qualified, "="); ok {
+		name, qualified = before, after
+
+		if !token.IsIdentifier(name) {
+			return "", "", "", fmt.Errorf("converter function %q must be imported with an identifier, got %q", qualified, name)
+		}
+	}
+
+	i := strings.LastIndex(qualified, ".")
+	if i <= 0 || i == len(qualified)-1 || strings.LastIndex(qualified, "/") > i {
+		return "", "", "", fmt.Errorf("converter function %q must be qualified with its import path, e.g. github.com/acme/money.DecimalToProto", qualified)
+	}
+
+	return name, qualified[:i], qualified[i+1:], nil
+}
+
+// majorVersionRegex matches the major version suffixes of the import paths: /v2 and the .v3 of gopkg.in/yaml.v3.
+var majorVersionRegex = regexp.MustCompile(`([/.])v[0-9]+$`)
+
+// guessPackageName is the name of a package that couldn't be loaded, from its import path without
+// the major version suffix, reduced to the characters of an identifier.
+func guessPackageName(importPath string) string {
+	base := path.Base(majorVersionRegex.ReplaceAllString(importPath, ""))
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+
+		return -1
+	}, base)
+
+	if name == "" || !token.IsIdentifier(name) {
+		return "pkg"
+	}
+
+	return name
+}
+
+// packageNames loads the packages of the converter functions without an explicit name, from the directory
+// of the generated package so its module resolves them, and returns their names by import path.
+// The packages which fail to load are left out, their names being guessed.
+func (g *generator) packageNames(dir string) map[string]string {
+	importPaths := make([]string, 0, len(g.converterFuncs)*2)
+
+	for _, funcs := range g.converterFuncs {
+		for _, qualified := range []string{funcs.ToProto, funcs.FromProto} {
+			if name, importPath, _, err := parseQualifiedFunc(qualified); err == nil && name == "" {
+				importPaths = append(importPaths, importPath)
+			}
+		}
+	}
+
+	names := make(map[string]string, len(importPaths))
+	if len(importPaths) == 0 {
+		return names
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName, Dir: dir}, importPaths...)
+	if err != nil {
+		return names
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) == 0 && pkg.Name != "" {
+			names[pkg.PkgPath] = pkg.Name
+		}
+	}
+
+	return names
+}
+
+func (g *generator) registerConverters(dir string) error {
+	g.converters = make(map[string]*converter, len(g.converterFuncs))
+
+	names := g.packageNames(dir)
+
+	for _, funcs := range g.converterFuncs {
+		c, err := newFuncConverter(funcs.ToProto, funcs.FromProto, names)
+		if err != nil {
+			return fmt.Errorf("converter of %s: %w", funcs.Model, err)
+		}
+
+		g.converters[funcs.Model] = c
+	}
+
+	return nil
+}
+
+// converterOf returns the conversion of the model type, the registered ones first, nil if there is none.
+func (g *generator) converterOf(t types.Type) *converter {
+	key := types.TypeString(t, nil)
+
+	if c, ok := g.converters[key]; ok {
+		return c
+	}
+
+	if g.wrappers {
+		if c, ok := wrapperConverters[key]; ok {
+			return c
+		}
+	}
+
+	return wellKnownConverters[key]
+}
+
+// fieldConverterOf returns the conversion of the field: the registered one of its type first, then the wrapper
+// of a field with the wrapper tag option, then the conversion of its type.
+// The wrapper tag option fails on a type without a wrapper.
+func (g *generator) fieldConverterOf(field *Field) (*converter, error) {
+	key := types.TypeString(field.Type, nil)
+
+	wrapper := wrapperConverters[key]
+	if key == "[]byte" {
+		wrapper = bytesWrapperConverter
+	}
+
+	if field.Tag.Wrapper && wrapper == nil {
+		return nil, fmt.Errorf("the wrapper tag option needs a pointer to a scalar or []byte, %s has no wrapper", key)
+	}
+
+	if c, ok := g.converters[key]; ok {
+		return c, nil
+	}
+
+	if field.Tag.Wrapper {
+		return wrapper, nil
+	}
+
+	return g.converterOf(field.Type), nil
+}
+
+// toProto generates the statements setting the proto expression from the model one.
+func (c *converter) toProto(model, proto string) (string, error) {
+	return executeConversion(c.ToProto, model, proto)
+}
+
+// fromProto generates the statements setting the model expression from the proto one.
+func (c *converter) fromProto(model, proto string) (string, error) {
+	return executeConversion(c.FromProto, model, proto)
+}
+
+// executeSample generates the proto value of a sample.
+func executeSample(tpl string, model, elem string) (string, error) {
+	t, err := template.New("sample").Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, &converterGenParameters{Model: model, Elem: elem}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func executeConversion(tpl string, model, proto string) (string, error) {
+	t, err := template.New("converter").Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, &converterGenParameters{Model: model, Proto: proto}); err != nil {
+		return "", err
+	}
+
+	return buf.String() + "\n", nil
+}
+
+// mergeImports adds the extra import paths to the sorted imports, once.
+func mergeImports(imports []string, extra []string) []string {
+	seen := make(map[string]struct{}, len(imports))
+	for _, imp := range imports {
+		seen[imp] = struct{}{}
+	}
+
+	for _, imp := range extra {
+		if _, ok := seen[imp]; ok {
+			continue
+		}
+
+		seen[imp] = struct{}{}
+		imports = append(imports, imp)
+	}
+
+	sort.Strings(imports)
+
+	return imports
+}
@@ -0,0 +1,145 @@
+package accessor
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"text/template"
+)
+
+// fuzzField is a primitive field the fuzzer generates the values of.
+type fuzzField struct {
+	Param      string
+	Type       string
+	ProtoField string
+	// ProtoValue is the parameter converted to the type of the proto field.
+	ProtoValue string
+	Seed       string
+}
+
+type fuzzGenParameters struct {
+	Struct  string
+	Package string
+	Fields  []*fuzzField
+}
+
+// fuzzReservedNames are the identifiers of the fuzz test a parameter can't be named after.
+var fuzzReservedNames = map[string]bool{
+	"f": true, "t": true, "model": true, "got": true, "reflect": true, "testing": true, "replaceMe": true, testPackage: true,
+}
+
+// fuzzProtoTypes are the Go types of the proto fields of the integers proto has no scalar of the same size for,
+// like the messages generated by accessory proto: int64 for int, int32 for int8 and int16, and so on.
+var fuzzProtoTypes = map[types.BasicKind]string{
+	types.Int:    "int64",
+	types.Int8:   "int32",
+	types.Int16:  "int32",
+	types.Uint:   "uint64",
+	types.Uint8:  "uint32",
+	types.Uint16: "uint32",
+}
+
+// newFuzzField returns the field as a parameter of the fuzz test, nil if the fuzzer can't generate its values.
+func (g *generator) newFuzzField(pkg *Package, field *Field, protoField string, n int) *fuzzField {
+	basic := g.fuzzBasic(pkg, field.Type)
+	if basic == nil {
+		return nil
+	}
+
+	param := field.Name
+	if fuzzReservedNames[param] {
+		param = param + "Value"
+	}
+
+	// f.Add needs the exact types of the parameters, the default types of the literals aside.
+	seed := basicSample(basic, field.Name, n).Model
+	switch basic.Kind() {
+	case types.String, types.Bool, types.Int:
+	default:
+		seed = fmt.Sprintf("%s(%s)", basic.Name(), seed)
+	}
+
+	protoValue := param
+	if protoType, ok := fuzzProtoTypes[basic.Kind()]; ok {
+		protoValue = fmt.Sprintf("%s(%s)", protoType, param)
+	}
+
+	return &fuzzField{
+		Param:      param,
+		Type:       basic.Name(),
+		ProtoField: protoField,
+		ProtoValue: protoValue,
+		Seed:       seed,
+	}
+}
+
+// fuzzBasic returns the basic type of a field the fuzzer can generate: strings, booleans and integers,
+// named or not. Floats are left out as NaN isn't equal to itself after the round trip.
+func (g *generator) fuzzBasic(pkg *Package, t types.Type) *types.Basic {
+	if g.converterOf(t) != nil {
+		return nil
+	}
+
+	var basic *types.Basic
+	switch t := t.(type) {
+	case *types.Basic:
+		basic = t
+	case *types.Named:
+		if g.model(pkg, t) != nil || g.hasEnumConverters(t) || g.isEnum(pkg, t) {
+			return nil
+		}
+
+		basic, _ = t.Underlying().(*types.Basic)
+	}
+
+	if basic == nil {
+		return nil
+	}
+
+	switch basic.Kind() {
+	case types.Bool, types.String,
+		types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+		types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+		return basic
+	}
+
+	return nil
+}
+
+// generateFuzzTest generates a fuzz test building the struct from the fuzzed primitive fields
+// and checking it is the same after converting it to proto and back.
+func (g *generator) generateFuzzTest(st *Struct, testParameters *testGenParameters, fields []*fuzzField) (string, error) {
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	var fuzzTestTemplate = `
+	func Fuzz{{.Struct}}_RoundTrip(f *testing.F) {
+		f.Add({{range $i, $field := .Fields}}{{if $i}}, {{end}}{{$field.Seed}}{{end}})
+		f.Fuzz(func(t *testing.T{{range .Fields}}, {{.Param}} {{.Type}}{{end}}) {
+			model := {{.Package}}.ProtoTo{{.Struct}}(&replaceMe.{{.Struct}}{
+				{{- range .Fields}}
+				{{.ProtoField}}: {{.ProtoValue}},
+				{{- end}}
+			})
+
+			got := {{.Package}}.ProtoTo{{.Struct}}(model.ToProto())
+			if !reflect.DeepEqual(got, model) {
+				t.Errorf("ProtoTo{{.Struct}}(ToProto()) = %v, want %v", got, model)
+			}
+		})
+	}`
+
+	t := template.Must(template.New("fuzzTest").Parse(fuzzTestTemplate))
+	buf := new(bytes.Buffer)
+
+	if err := t.Execute(buf, &fuzzGenParameters{
+		Struct:  st.Name,
+		Package: testParameters.Package,
+		Fields:  fields,
+	}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -2,10 +2,11 @@ package accessor
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"go/types"
+	"io"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"text/template"
@@ -14,14 +15,33 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"golang.org/x/tools/go/packages"
+
+	"github.com/masaushi/accessory/internal/testgen"
 )
 
+// testPackage is the name the generated tests give to the package of the struct.
+const testPackage = "models"
+
 type generator struct {
 	writer   *writer
 	typ      string
 	output   string
 	receiver string
 	lock     string
+	// lockPointer is set when the lock is a pointer, nil in the models the generated tests make.
+	lockPointer bool
+	wrappers    bool
+	// testStyle is the style of the generated tests, see testgen.Styles.
+	testStyle string
+	fuzz      bool
+	bench     bool
+	sink      io.Writer
+	dryRun    bool
+
+	converterFuncs []*converterFuncs
+	converters     map[string]*converter
+	// imports are the packages needed by the conversions on top of the ones of the fields.
+	imports []string
 }
 
 type methodGenParameters struct {
@@ -31,29 +51,47 @@ type methodGenParameters struct {
 	GetterMethod string
 	SetterMethod string
 	Type         string
+	TestType     string // used only when generating stuff for tester
 	ZeroValue    string // used only when generating getter
 	EmptyValue   string // used only when generating stuff for tester
 	Lock         string
 }
 
 type testGenParameters struct {
-	Receiver      string
-	Struct        string
-	Package       string
-	WantStruct    string
-	AssertTest    string
-	NilTestData   string
-	EmptyTestData string
+	Receiver       string
+	Struct         string
+	Package        string
+	WantStruct     string
+	AssertTest     string
+	NilTestData    string
+	EmptyTestData  string
+	ToProtoBody    string
+	ProtoToBody    string
+	OneofTests     string
+	FuzzTest       string
+	ModelHelpers   string
+	EmptyProto     string
+	NonNilTestData string
+	NonNilProto    string
+	// ConcurrentCalls are the accessor calls of the concurrent access test, generated with a lock.
+	ConcurrentCalls string
+	Benchmarks      []*benchmarkGenParameters
+}
+
+// benchmarkGenParameters is the benchmark of an accessor, Call calling it on model.
+type benchmarkGenParameters struct {
+	Method string
+	Call   string
 }
 
 func newGenerator(fs afero.Fs, pkg *Package, options ...Option) *generator {
-	g := new(generator)
+	g := &generator{testStyle: testgen.StyleGt}
 	for _, opt := range options {
 		opt(g)
 	}
 
 	path := g.outputFilePath(pkg.Dir)
-	g.writer = newWriter(fs, path)
+	g.writer = newWriter(fs, path, g.sink, g.dryRun)
 
 	return g
 }
@@ -61,13 +99,12 @@ func newGenerator(fs afero.Fs, pkg *Package, options ...Option) *generator {
 // Generate generates a file and accessor methods.
 func Generate(fs afero.Fs, pkg *Package, options ...Option) error {
 	g := newGenerator(fs, pkg, options...)
+	if g.dryRun && g.sink == nil {
+		return errors.New("the dry run needs a sink to list the output file to")
+	}
 
-	fmt.Printf("starting pkg\n")
-	for _, field := range pkg.Structs[0].Fields {
-		fmt.Printf("field: %+v\n", field)
-		if field.Tag != nil {
-			fmt.Printf("getter: %s\n", *(field.Tag.Getter))
-		}
+	if err := g.registerConverters(pkg.Dir); err != nil {
+		return err
 	}
 
 	accessors := make([]string, 0)
@@ -78,7 +115,7 @@ func Generate(fs afero.Fs, pkg *Package, options ...Option) error {
 		Receiver: strings.ToLower(g.typ),
 		Struct:   g.typ,
 		// this package is hard coded.
-		Package: "models",
+		Package: testPackage,
 	}
 
 	for _, st := range pkg.Structs {
@@ -86,11 +123,22 @@ func Generate(fs afero.Fs, pkg *Package, options ...Option) error {
 			continue
 		}
 
-		for _, field := range st.Fields {
+		if g.lock != "" {
+			if err := g.validateLock(pkg, st); err != nil {
+				return err
+			}
+		}
+
+		for i, field := range st.Fields {
 			if field.Tag == nil {
 				continue
 			}
 
+			// The lock and the other sync types must not be copied, they get no accessor nor test.
+			if g.skipConversion(field) {
+				continue
+			}
+
 			params := g.setupParameters(pkg, st, field)
 
 			if field.Tag.Getter != nil {
@@ -108,9 +156,26 @@ func Generate(fs afero.Fs, pkg *Package, options ...Option) error {
 				accessors = append(accessors, setter)
 			}
 
-			err := g.updateTestComponent(params, testParameters)
-			if err != nil {
-				return err
+			if field.Tag.Getter != nil {
+				err := g.updateTestComponent(params, testParameters)
+				if err != nil {
+					return err
+				}
+			}
+
+			if g.lock != "" {
+				calls, err := g.concurrentCalls(pkg, field, params, i+1)
+				if err != nil {
+					return fmt.Errorf("%s.%s: %w", st.Name, field.Name, err)
+				}
+
+				testParameters.ConcurrentCalls = testParameters.ConcurrentCalls + calls
+			}
+
+			if g.bench {
+				if err := g.updateBenchmarks(pkg, field, params, testParameters, i+1); err != nil {
+					return fmt.Errorf("%s.%s: %w", st.Name, field.Name, err)
+				}
 			}
 
 			replacer := strings.NewReplacer(
@@ -122,6 +187,10 @@ func Generate(fs afero.Fs, pkg *Package, options ...Option) error {
 				usedPkgs = append(usedPkgs, typePaths[0])
 			}
 		}
+
+		if err := g.generateConversion(pkg, st, testParameters); err != nil {
+			return err
+		}
 	}
 
 	generatedTest, err := g.assembleTest(testParameters)
@@ -132,20 +201,37 @@ func Generate(fs afero.Fs, pkg *Package, options ...Option) error {
 	accessors = append(accessors, generatedTest)
 
 	imports := g.generateImportStrings(pkg.Imports, usedPkgs)
+	imports = mergeImports(imports, g.imports)
+	imports = mergeImports(imports, g.testImports(testParameters))
 	return g.writer.write(pkg.Name, imports, accessors)
 }
 
+// testImports returns the packages the generated tests, benchmarks and fuzz test use.
+func (g *generator) testImports(params *testGenParameters) []string {
+	imports := testgen.Imports(g.testStyle)
+
+	// Test<Struct>_ConcurrentAccess waits for its goroutines with a sync.WaitGroup.
+	if g.concurrentTest() {
+		imports = append(imports, "sync")
+	}
+
+	if params.FuzzTest != "" {
+		imports = append(imports, "reflect", "testing")
+	}
+
+	if g.bench {
+		imports = append(imports, "testing")
+	}
+
+	return imports
+}
+
 func (g *generator) outputFilePath(dir string) string {
 	output := g.output
 	if output == "" {
 		// Use snake_case name of type as output file if output file is not specified.
 		// type TestStruct will be test_struct_accessor.go
-		var firstCapMatcher = regexp.MustCompile("(.)([A-Z][a-z]+)")
-		var articleCapMatcher = regexp.MustCompile("([a-z0-9])([A-Z])")
-
-		name := firstCapMatcher.ReplaceAllString(g.typ, "${1}_${2}")
-		name = articleCapMatcher.ReplaceAllString(name, "${1}_${2}")
-		output = strings.ToLower(fmt.Sprintf("%s_accessor.go", name))
+		output = fmt.Sprintf("%s_accessor.go", snakeCase(g.typ))
 	}
 
 	return filepath.Join(dir, output)
@@ -219,10 +305,7 @@ func (g *generator) updateTestComponent(
 	testParameters *testGenParameters,
 ) error {
 	var (
-		wantStructTemplate = `want{{.Field}} {{.Type}}`
-		assertTemplate     = `got{{.Field}} := ctx.testData.args.Get{{.Field}}()
-			assert.Equal(t, ctx.testData.want{{.Field}}, got{{.Field}})
-		`
+		wantStructTemplate    = `want{{.Field}} {{.TestType}}`
 		nilTestDataTemplate   = `want{{.Field}}: {{.ZeroValue}},`
 		emptyTestDataTemplate = `want{{.Field}}: {{.EmptyValue}},`
 	)
@@ -234,12 +317,9 @@ func (g *generator) updateTestComponent(
 		return err
 	}
 
-	assertTemplateExecutor := template.Must(template.New("assert").Parse(assertTemplate))
-	bufAssert := new(bytes.Buffer)
-
-	if err := assertTemplateExecutor.Execute(bufAssert, params); err != nil {
-		return err
-	}
+	data := testgen.Data(g.testStyle)
+	assert := fmt.Sprintf("got%s := %s.args.%s()\n%s\n", params.Field, data, params.GetterMethod,
+		testgen.Equal(g.testStyle, data+".want"+params.Field, "got"+params.Field))
 
 	nilTestDataTemplateExecutor := template.Must(template.New("nilTest").Parse(nilTestDataTemplate))
 	bufNilTestData := new(bytes.Buffer)
@@ -257,12 +337,12 @@ func (g *generator) updateTestComponent(
 
 	if testParameters.WantStruct == "" {
 		testParameters.WantStruct = testParameters.WantStruct + bufWantStruct.String()
-		testParameters.AssertTest = testParameters.AssertTest + bufAssert.String()
+		testParameters.AssertTest = testParameters.AssertTest + assert
 		testParameters.NilTestData = testParameters.NilTestData + bufNilTestData.String()
 		testParameters.EmptyTestData = testParameters.EmptyTestData + bufEmptyTestData.String()
 	} else {
 		testParameters.WantStruct = testParameters.WantStruct + "\n" + bufWantStruct.String()
-		testParameters.AssertTest = testParameters.AssertTest + "\n" + bufAssert.String()
+		testParameters.AssertTest = testParameters.AssertTest + "\n" + assert
 		testParameters.NilTestData = testParameters.NilTestData + "\n" + bufNilTestData.String()
 		testParameters.EmptyTestData = testParameters.EmptyTestData + "\n" + bufEmptyTestData.String()
 	}
@@ -273,13 +353,14 @@ func (g *generator) updateTestComponent(
 func (g *generator) assembleTest(
 	params *testGenParameters,
 ) (string, error) {
-	var getTestTemplate = `
+	var conversionTemplate = `
 	// ToProto converts {{.Struct}} to the Protobuf version.
 	func ({{.Receiver}} *{{.Struct}}) ToProto() *replaceMe.{{.Struct}} {
 		if {{.Receiver}} == nil {
 			return nil
 		}
 
+		{{.ToProtoBody}}
 	}
 
 	// ProtoTo{{.Struct}} converts from Protobuf version to the {{.Struct}}.
@@ -287,60 +368,150 @@ func (g *generator) assembleTest(
 		if {{.Receiver}} == nil {
 			return nil
 		}
+
+		{{.ProtoToBody}}
 	}
+	{{.ModelHelpers}}`
 
-	func Test{{.Struct}}_GetFunctions(t *testing.T) {
-		type want struct {
-			args *{{.Package}}.{{.Struct}}
-			{{.WantStruct}}
-			wantProto *replaceMe.{{.Struct}}
+	t := template.Must(template.New("conversion").Parse(conversionTemplate))
+	buf := new(bytes.Buffer)
+
+	if err := t.Execute(buf, params); err != nil {
+		return "", err
+	}
+
+	data := testgen.Data(g.testStyle)
+	test, err := testgen.Render(g.testStyle, &testgen.Test{
+		Name:        params.Struct + "_GetFunctions",
+		Description: "Get functions return proper value",
+		Want: fmt.Sprintf("args *%s.%s\n%s\nwantProto *replaceMe.%s",
+			params.Package, params.Struct, params.WantStruct, params.Struct),
+		Cases: []*testgen.Case{
+			{
+				Name: "given nil value",
+				Data: fmt.Sprintf("args: nil,\n%s\nwantProto: nil,", params.NilTestData),
+			},
+			{
+				Name: "given empty value",
+				Data: fmt.Sprintf("args: &%s.%s{},\n%s\nwantProto: &replaceMe.%s{\n%s\n},",
+					params.Package, params.Struct, params.EmptyTestData, params.Struct, params.EmptyProto),
+			},
+			{
+				Name: "given NON nil value",
+				Data: fmt.Sprintf("args: %s.ProtoTo%s(&replaceMe.%s{\n%s\n}),\n%s\nwantProto: &replaceMe.%s{\n%s\n},",
+					params.Package, params.Struct, params.Struct, params.NonNilProto, params.NonNilTestData, params.Struct, params.NonNilProto),
+			},
+		},
+		Body: fmt.Sprintf(`// GET functions
+			%s
+
+			// Convert from models to Proto.
+			gotProto := %s.args.ToProto()
+			%s
+
+			// Then convert from Proto back to model
+			gotModel := %s.ProtoTo%s(gotProto)
+			%s`,
+			params.AssertTest,
+			data, testgen.Equal(g.testStyle, data+".wantProto", "gotProto"),
+			params.Package, params.Struct, testgen.Equal(g.testStyle, data+".args", "gotModel")),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if g.concurrentTest() {
+		concurrentTest, err := g.generateConcurrentTest(params)
+		if err != nil {
+			return "", err
 		}
-	
-		type Context struct {
-			testData *want
+
+		test = test + "\n" + concurrentTest
+	}
+
+	benchmarks, err := g.generateBenchmarks(params)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String() + "\n" + test + params.OneofTests + params.FuzzTest + benchmarks, nil
+}
+
+// updateBenchmarks adds the benchmarks of the getter and the setter of the field, setters being given its sample.
+func (g *generator) updateBenchmarks(
+	pkg *Package,
+	field *Field,
+	params *methodGenParameters,
+	testParameters *testGenParameters,
+	n int,
+) error {
+	if field.Tag.Getter != nil {
+		testParameters.Benchmarks = append(testParameters.Benchmarks, &benchmarkGenParameters{
+			Method: params.GetterMethod,
+			Call:   fmt.Sprintf("_ = model.%s()", params.GetterMethod),
+		})
+	}
+
+	if field.Tag.Setter != nil {
+		sample, err := g.sampleOf(pkg, field.Type, field.Name, n)
+		if err != nil {
+			return err
+		}
+
+		testParameters.Benchmarks = append(testParameters.Benchmarks, &benchmarkGenParameters{
+			Method: params.SetterMethod,
+			Call:   fmt.Sprintf("model.%s(%s)", params.SetterMethod, sample.Model),
+		})
+	}
+
+	return nil
+}
+
+// generateBenchmarks generates the benchmarks of the accessors and of the conversions,
+// run on the struct of the NON nil test case.
+func (g *generator) generateBenchmarks(params *testGenParameters) (string, error) {
+	if !g.bench {
+		return "", nil
+	}
+
+	var benchmarkTemplate = `
+	{{- range .Benchmarks}}
+
+	func Benchmark{{$.Struct}}_{{.Method}}(b *testing.B) {
+		model := {{$.Package}}.ProtoTo{{$.Struct}}(&replaceMe.{{$.Struct}}{
+			{{$.NonNilProto}}
+		})
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			{{.Call}}
 		}
-	
-		contextInitiateFunction := func(t *testing.T) *Context {
-			return &Context{}
+	}
+	{{- end}}
+
+	func Benchmark{{.Struct}}_ToProto(b *testing.B) {
+		model := {{.Package}}.ProtoTo{{.Struct}}(&replaceMe.{{.Struct}}{
+			{{.NonNilProto}}
+		})
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = model.ToProto()
+		}
+	}
+
+	func Benchmark{{.Struct}}_ProtoTo{{.Struct}}(b *testing.B) {
+		proto := &replaceMe.{{.Struct}}{
+			{{.NonNilProto}}
 		}
 
-		gt.Begin(t,
-			contextInitiateFunction,
-			gt.Run("Get functions return proper value", func(t *testing.T, ctx *Context) {
-				// GET functions
-				{{.AssertTest}}
-
-				// Convert from models to Proto.
-				gotProto := ctx.testData.args.ToProto()
-				assert.Equal(t, ctx.testData.wantProto, gotProto)
-
-				// Then convert from Proto back to model
-				gotModel := models.ProtoTo{{.Struct}}(gotProto)
-				assert.Equal(t, ctx.testData.args, gotModel)
-			}).
-				Using("given nil value", func(t *testing.T, ctx *Context) {
-					ctx.testData = &want{
-						args: nil,
-						{{.NilTestData}}
-						wantProto: nil,
-					}
-				}).
-				Using("given empty value", func(t *testing.T, ctx *Context) {
-					ctx.testData = &want{
-						args: &{{.Package}}.{{.Struct}}{},
-						{{.EmptyTestData}}
-						wantProto: &replaceMe.{{.Struct}}{},
-					}
-				}).
-				Using("given NON nil value", func(t *testing.T, ctx *Context) {
-					ctx.testData = &want{
-
-					}
-				}),
-		)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = {{.Package}}.ProtoTo{{.Struct}}(proto)
+		}
 	}`
 
-	t := template.Must(template.New("tester").Parse(getTestTemplate))
+	t := template.Must(template.New("benchmark").Parse(benchmarkTemplate))
 	buf := new(bytes.Buffer)
 
 	if err := t.Execute(buf, params); err != nil {
@@ -356,9 +527,6 @@ func (g *generator) setupParameters(
 	field *Field,
 ) *methodGenParameters {
 	typeName := g.typeName(pkg.Types, field.Type)
-	fmt.Println("pkg.Types: ", pkg.Types)
-	fmt.Println("field.Type: ", field.Type)
-	fmt.Println("typeName: ", typeName)
 	getter, setter := g.methodNames(field)
 	return &methodGenParameters{
 		Receiver:     g.receiverName(st.Name),
@@ -367,6 +535,7 @@ func (g *generator) setupParameters(
 		GetterMethod: getter,
 		SetterMethod: setter,
 		Type:         typeName,
+		TestType:     g.testTypeName(pkg, field.Type),
 		ZeroValue:    g.zeroValue(field.Type, typeName),
 		EmptyValue:   g.emptyValue(field.Type, typeName),
 		Lock:         g.lock,
@@ -374,8 +543,6 @@ func (g *generator) setupParameters(
 }
 
 func (g *generator) receiverName(structName string) string {
-	fmt.Println("structName: ", structName)
-
 	if g.receiver != "" {
 		// Do nothing if receiver name specified in args.
 		return g.receiver
@@ -403,8 +570,6 @@ func (g *generator) methodNames(field *Field) (getter, setter string) {
 
 func (g *generator) typeName(pkg *types.Package, t types.Type) string {
 	return types.TypeString(t, func(p *types.Package) string {
-		fmt.Println("pkg: ", pkg)
-		fmt.Println("p: ", p)
 		// type is defined in the same package
 		if pkg == p {
 			return ""
@@ -0,0 +1,110 @@
+package accessor
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"strings"
+	"text/template"
+
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+type concurrentTestGenParameters struct {
+	Struct  string
+	Package string
+	Calls   string
+}
+
+// validateLock checks the lock field of the struct has the Lock and Unlock methods the accessors call,
+// like sync.Mutex and sync.RWMutex or pointers to them.
+func (g *generator) validateLock(pkg *Package, st *Struct) error {
+	for _, field := range st.Fields {
+		if field.Name != g.lock {
+			continue
+		}
+
+		for _, method := range []string{"Lock", "Unlock"} {
+			obj, _, _ := types.LookupFieldOrMethod(field.Type, true, pkg.Types, method)
+
+			fn, ok := obj.(*types.Func)
+			if ok {
+				signature := fn.Type().(*types.Signature)
+				ok = signature.Params().Len() == 0 && signature.Results().Len() == 0
+			}
+
+			if !ok {
+				return fmt.Errorf("%s.%s: %s has no %s() method to lock the accessors with, use a sync.Mutex or a sync.RWMutex",
+					st.Name, field.Name, g.typeName(pkg.Types, field.Type), method)
+			}
+		}
+
+		_, g.lockPointer = field.Type.(*types.Pointer)
+
+		return nil
+	}
+
+	return fmt.Errorf("%s has no lock field %s", st.Name, g.lock)
+}
+
+// concurrentTest reports whether the concurrent access test is generated. It isn't for a pointer lock,
+// the test can't set the unexported lock of the model it makes and the accessors would dereference nil.
+func (g *generator) concurrentTest() bool {
+	return g.lock != "" && !g.lockPointer && g.testStyle != testgen.StyleNone
+}
+
+// concurrentCalls generates the calls of the accessors of the field made by every goroutine
+// of the concurrent access test, setters being given the sample of the field.
+func (g *generator) concurrentCalls(pkg *Package, field *Field, params *methodGenParameters, n int) (string, error) {
+	calls := ""
+
+	if field.Tag.Setter != nil {
+		sample, err := g.sampleOf(pkg, field.Type, field.Name, n)
+		if err != nil {
+			return "", err
+		}
+
+		calls = calls + fmt.Sprintf("model.%s(%s)\n", params.SetterMethod, sample.Model)
+	}
+
+	if field.Tag.Getter != nil {
+		calls = calls + fmt.Sprintf("_ = model.%s()\n", params.GetterMethod)
+	}
+
+	return calls, nil
+}
+
+// generateConcurrentTest generates a test calling every accessor from many goroutines at once,
+// for the race detector to prove the lock guards them.
+func (g *generator) generateConcurrentTest(params *testGenParameters) (string, error) {
+	var concurrentTestTemplate = `
+	// Test{{.Struct}}_ConcurrentAccess calls the accessors from many goroutines, run it with go test -race.
+	func Test{{.Struct}}_ConcurrentAccess(t *testing.T) {
+		model := &{{.Package}}.{{.Struct}}{}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				{{.Calls}}
+			}()
+		}
+
+		wg.Wait()
+	}`
+
+	t := template.Must(template.New("concurrentTest").Parse(concurrentTestTemplate))
+	buf := new(bytes.Buffer)
+
+	if err := t.Execute(buf, &concurrentTestGenParameters{
+		Struct:  params.Struct,
+		Package: params.Package,
+		Calls:   strings.TrimSuffix(params.ConcurrentCalls, "\n"),
+	}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
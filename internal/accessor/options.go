@@ -1,5 +1,7 @@
 package accessor
 
+import "io"
+
 type Option func(*generator)
 
 // Type sets type name to genarator.
@@ -16,6 +18,21 @@ func Output(output string) Option {
 	}
 }
 
+// Sink makes genarator write the generated source to w instead of the output file.
+func Sink(w io.Writer) Option {
+	return func(g *generator) {
+		g.sink = w
+	}
+}
+
+// DryRun makes genarator list the output file and the functions it would declare to the sink instead of writing it,
+// Generate fails without a Sink.
+func DryRun(dryRun bool) Option {
+	return func(g *generator) {
+		g.dryRun = dryRun
+	}
+}
+
 // Receiver sets receiver name to genarator.
 func Receiver(receiver string) Option {
 	return func(g *generator) {
@@ -29,3 +46,46 @@ func Lock(lock string) Option {
 		g.lock = lock
 	}
 }
+
+// Wrappers makes genarator convert the pointers to scalars to the google.protobuf wrappers
+// instead of proto3 optional fields.
+func Wrappers(wrappers bool) Option {
+	return func(g *generator) {
+		g.wrappers = wrappers
+	}
+}
+
+// TestStyle sets the style of the generated tests to genarator, one of testgen.Styles.
+func TestStyle(style string) Option {
+	return func(g *generator) {
+		g.testStyle = style
+	}
+}
+
+// Fuzz makes genarator generate a fuzz test of the conversions, over the primitive fields.
+func Fuzz(fuzz bool) Option {
+	return func(g *generator) {
+		g.fuzz = fuzz
+	}
+}
+
+// Bench makes genarator generate benchmarks of the accessors and of the conversions.
+func Bench(bench bool) Option {
+	return func(g *generator) {
+		g.bench = bench
+	}
+}
+
+// Converter registers the pair of functions converting the model type to its proto type and back,
+// given with their import path: github.com/acme/money.DecimalToProto, and the name to import the package with
+// when it isn't the one go list finds: money=github.com/acme/money/v2.DecimalToProto.
+// The model type is written with its import path too: github.com/shopspring/decimal.Decimal.
+func Converter(model, toProto, fromProto string) Option {
+	return func(g *generator) {
+		g.converterFuncs = append(g.converterFuncs, &converterFuncs{
+			Model:     model,
+			ToProto:   toProto,
+			FromProto: fromProto,
+		})
+	}
+}
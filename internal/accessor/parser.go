@@ -1,40 +1,48 @@
 package accessor
 
 import (
+	"errors"
 	"fmt"
+	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
 )
 
 const (
-	accessorTag  = "accessor"
-	ignoreTag    = "-"
-	tagKeyGetter = "getter"
-	tagKeySetter = "setter"
+	accessorTag   = "accessor"
+	ignoreTag     = "-"
+	tagKeyGetter  = "getter"
+	tagKeySetter  = "setter"
+	tagKeyOneof   = "oneof"
+	tagKeyWrapper = "wrapper"
 )
 
 const (
 	tagSep         = ","
 	tagKeyValueSep = ":"
+	// tagOneofSep separates oneof from its name, as ":" gives the method name of getter and setter.
+	tagOneofSep = "="
 )
 
+const loadMode = packages.NeedName | packages.NeedFiles |
+	packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax
+
 // ParsePackage parses the specified directory's package.
 func ParsePackage(dir string) (*Package, error) {
-	const mode = packages.NeedName | packages.NeedFiles |
-		packages.NeedImports | packages.NeedTypes | packages.NeedSyntax
-
 	dir, err := filepath.Abs(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	cfg := &packages.Config{
-		Mode:  mode,
+		Mode:  loadMode,
 		Tests: false,
 	}
 	pkgs, err := packages.Load(cfg, dir)
@@ -45,14 +53,54 @@ func ParsePackage(dir string) (*Package, error) {
 		return nil, fmt.Errorf("error: %d packages found", len(pkgs))
 	}
 
+	return newPackage(pkgs[0], dir), nil
+}
+
+// ParsePackages parses every package matching the patterns, directories, import paths or patterns
+// like ./..., loaded at once. Packages without Go files are left out. The errors listing, parsing and type checking
+// the packages are returned together with the packages that could be parsed, which are generated anyway.
+func ParsePackages(patterns ...string) ([]*Package, error) {
+	cfg := &packages.Config{
+		Mode:  loadMode,
+		Tests: false,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no package matches %s", strings.Join(patterns, " "))
+	}
+
+	result := make([]*Package, 0, len(pkgs))
+	errs := make([]error, 0)
+
+	for _, pkg := range pkgs {
+		for _, pkgErr := range pkg.Errors {
+			errs = append(errs, fmt.Errorf("%s: %w", pkg.PkgPath, pkgErr))
+		}
+
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		result = append(result, newPackage(pkg, filepath.Dir(pkg.GoFiles[0])))
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func newPackage(pkg *packages.Package, dir string) *Package {
 	return &Package{
-		Package: pkgs[0],
+		Package: pkg,
 		Dir:     dir,
-		Structs: parseStructs(pkgs[0]),
-	}, nil
+		Structs: parseStructs(pkg),
+		Enums:   parseEnums(pkg),
+	}
 }
 
 func parseStructs(pkg *packages.Package) []*Struct {
+	comments := parseDocComments(pkg.Syntax)
 	scope := pkg.Types.Scope()
 	structs := make([]*Struct, 0, len(scope.Names()))
 	for _, name := range scope.Names() {
@@ -62,14 +110,126 @@ func parseStructs(pkg *packages.Package) []*Struct {
 		}
 
 		structs = append(structs, &Struct{
-			Name:   name,
-			Fields: parseFields(pkg.Fset, st),
+			Name:    name,
+			Comment: comments[name],
+			Fields:  parseFields(pkg.Fset, st),
+			Tagged:  hasAccessorTag(st),
 		})
 	}
 
 	return structs
 }
 
+// parseEnums finds the named integer types that have constants of their type, sorted by name.
+func parseEnums(pkg *packages.Package) []*Enum {
+	comments := parseDocComments(pkg.Syntax)
+	scope := pkg.Types.Scope()
+
+	enums := make(map[*types.TypeName]*Enum)
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.Const)
+		if !ok {
+			continue
+		}
+
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.Obj().Pkg() != pkg.Types {
+			continue
+		}
+
+		basic, ok := named.Underlying().(*types.Basic)
+		if !ok || basic.Info()&types.IsInteger == 0 {
+			continue
+		}
+
+		value, ok := constant.Int64Val(obj.Val())
+		if !ok {
+			continue
+		}
+
+		enum, ok := enums[named.Obj()]
+		if !ok {
+			enum = &Enum{
+				Name:    named.Obj().Name(),
+				Comment: comments[named.Obj().Name()],
+			}
+			enums[named.Obj()] = enum
+		}
+
+		enum.Constants = append(enum.Constants, &Constant{
+			Name:    name,
+			Value:   value,
+			Comment: comments[name],
+		})
+	}
+
+	result := make([]*Enum, 0, len(enums))
+	for _, enum := range enums {
+		// The scope is sorted by name, the declaration order is what the reader expects.
+		sort.SliceStable(enum.Constants, func(i, j int) bool {
+			return scope.Lookup(enum.Constants[i].Name).Pos() < scope.Lookup(enum.Constants[j].Name).Pos()
+		})
+
+		result = append(result, enum)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// parseDocComments returns the doc comments of the top level types and constants, keyed by name.
+// A declaration with a single spec can have its comment on the declaration itself.
+func parseDocComments(files []*ast.File) map[string]string {
+	comments := make(map[string]string)
+
+	text := func(groups ...*ast.CommentGroup) string {
+		for _, group := range groups {
+			if group == nil {
+				continue
+			}
+
+			lines := make([]string, 0, len(group.List))
+			for _, comment := range group.List {
+				lines = append(lines, comment.Text)
+			}
+
+			return strings.Join(lines, "\n")
+		}
+
+		return ""
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.TYPE && gen.Tok != token.CONST) {
+				continue
+			}
+
+			var declDoc *ast.CommentGroup
+			if len(gen.Specs) == 1 {
+				declDoc = gen.Doc
+			}
+
+			for _, spec := range gen.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					comments[spec.Name.Name] = text(spec.Doc, declDoc)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						comments[name.Name] = text(spec.Doc, declDoc)
+					}
+				}
+			}
+		}
+	}
+
+	return comments
+}
+
 func parseFields(fset *token.FileSet, st *types.Struct) []*Field {
 	fields := make([]*Field, st.NumFields())
 	for i := 0; i < st.NumFields(); i++ {
@@ -77,15 +237,27 @@ func parseFields(fset *token.FileSet, st *types.Struct) []*Field {
 		field := st.Field(i)
 
 		fields[i] = &Field{
-			Name: field.Name(),
-			Type: field.Type(),
-			Tag:  tag,
+			Name:     field.Name(),
+			Type:     field.Type(),
+			Tag:      tag,
+			Embedded: field.Embedded(),
 		}
 	}
 
 	return fields
 }
 
+// hasAccessorTag reports whether a field of the struct has the accessor tag.
+func hasAccessorTag(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if _, ok := reflect.StructTag(strings.Trim(st.Tag(i), "`")).Lookup(accessorTag); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func parseTag(tag string) *Tag {
 	tagStr, ok := reflect.StructTag(strings.Trim(tag, "`")).Lookup(accessorTag)
 	if !ok {
@@ -97,10 +269,20 @@ func parseTag(tag string) *Tag {
 		}
 	}
 
-	var getter, setter *string
+	var (
+		getter, setter *string
+		oneof          string
+		wrapper        bool
+	)
 
 	tags := strings.Split(tagStr, tagSep)
 	for _, tag := range tags {
+		if key, value, ok := strings.Cut(tag, tagOneofSep); ok && strings.TrimSpace(key) == tagKeyOneof {
+			oneof = strings.TrimSpace(value)
+
+			continue
+		}
+
 		keyValue := strings.Split(tag, tagKeyValueSep)
 
 		var value string
@@ -114,8 +296,10 @@ func parseTag(tag string) *Tag {
 			getter = &value
 		case tagKeySetter:
 			setter = &value
+		case tagKeyWrapper:
+			wrapper = true
 		}
 	}
 
-	return &Tag{Setter: setter, Getter: getter}
+	return &Tag{Setter: setter, Getter: getter, Oneof: oneof, Wrapper: wrapper}
 }
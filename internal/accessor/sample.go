@@ -0,0 +1,285 @@
+package accessor
+
+import (
+	"fmt"
+	"go/constant"
+	"go/types"
+)
+
+// sample is a deterministic value of a field for the generated tests, as the model and as proto.
+type sample struct {
+	Model string
+	Proto string
+}
+
+// sampleOf returns a sample of the type: the name of the field for strings, n for numbers, true for bools,
+// an empty message for models, a single element for slices and maps, and a pointer to the sample for pointers.
+// Types without a meaningful sample get their zero value.
+func (g *generator) sampleOf(pkg *Package, t types.Type, name string, n int) (*sample, error) {
+	if c := g.converterOf(t); c != nil {
+		return g.converterSample(pkg, c, t, name, n)
+	}
+
+	return g.typeSample(pkg, t, name, n)
+}
+
+// fieldSampleOf returns the sample of the field, converted like the field is.
+func (g *generator) fieldSampleOf(pkg *Package, field *Field, n int) (*sample, error) {
+	c, err := g.fieldConverterOf(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if c != nil {
+		return g.converterSample(pkg, c, field.Type, field.Name, n)
+	}
+
+	return g.typeSample(pkg, field.Type, field.Name, n)
+}
+
+// typeSample samples the type as it is, regardless of its converter.
+func (g *generator) typeSample(pkg *Package, t types.Type, name string, n int) (*sample, error) {
+	switch t := t.(type) {
+	case *types.Basic:
+		return basicSample(t, name, n), nil
+	case *types.Pointer:
+		if named := g.model(pkg, t.Elem()); named != nil {
+			proto := fmt.Sprintf("&replaceMe.%s{}", named.Obj().Name())
+
+			return &sample{
+				Model: fmt.Sprintf("%s(%s)", g.testFunc(pkg, named, "ProtoTo"+named.Obj().Name()), proto),
+				Proto: proto,
+			}, nil
+		}
+
+		elem, err := g.sampleOf(pkg, t.Elem(), name, n)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sample{
+			Model: pointerSample(g.testTypeName(pkg, t.Elem()), elem.Model),
+			Proto: pointerSample(g.protoTypeName(pkg, t.Elem()), elem.Proto),
+		}, nil
+	case *types.Named:
+		return g.namedSample(pkg, t, name, n)
+	case *types.Slice:
+		if basic, ok := t.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			value := fmt.Sprintf("[]byte(%q)", name)
+
+			return &sample{Model: value, Proto: value}, nil
+		}
+
+		if !g.hasCollectionSample(pkg, t.Elem()) {
+			break
+		}
+
+		elem, err := g.sampleOf(pkg, t.Elem(), name, n)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sample{
+			Model: fmt.Sprintf("[]%s{%s}", g.testTypeName(pkg, t.Elem()), elem.Model),
+			Proto: fmt.Sprintf("[]%s{%s}", g.protoTypeName(pkg, t.Elem()), elem.Proto),
+		}, nil
+	case *types.Map:
+		if !g.hasCollectionSample(pkg, t.Key()) || !g.hasCollectionSample(pkg, t.Elem()) {
+			break
+		}
+
+		key, err := g.sampleOf(pkg, t.Key(), name, n)
+		if err != nil {
+			return nil, err
+		}
+
+		elem, err := g.sampleOf(pkg, t.Elem(), name, n)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sample{
+			Model: fmt.Sprintf("map[%s]%s{%s: %s}", g.testTypeName(pkg, t.Key()), g.testTypeName(pkg, t.Elem()), key.Model, elem.Model),
+			Proto: fmt.Sprintf("map[%s]%s{%s: %s}", g.protoTypeName(pkg, t.Key()), g.protoTypeName(pkg, t.Elem()), key.Proto, elem.Proto),
+		}, nil
+	}
+
+	zero := g.zeroSample(pkg, t)
+
+	return &sample{Model: zero, Proto: zero}, nil
+}
+
+// namedSample samples models, enums and named scalars.
+func (g *generator) namedSample(pkg *Package, named *types.Named, name string, n int) (*sample, error) {
+	if g.model(pkg, named) != nil {
+		proto := fmt.Sprintf("&replaceMe.%s{}", named.Obj().Name())
+
+		return &sample{
+			Model: fmt.Sprintf("*%s(%s)", g.testFunc(pkg, named, "ProtoTo"+named.Obj().Name()), proto),
+			Proto: proto,
+		}, nil
+	}
+
+	if g.hasEnumConverters(named) {
+		value := g.testFunc(pkg, named, enumSampleConstant(named))
+
+		return &sample{Model: value, Proto: value + ".ToProto()"}, nil
+	}
+
+	if basic, ok := named.Underlying().(*types.Basic); ok {
+		value := basicSample(basic, name, n)
+
+		return &sample{
+			Model: fmt.Sprintf("%s(%s)", g.testTypeName(pkg, named), value.Model),
+			Proto: value.Proto,
+		}, nil
+	}
+
+	zero := g.zeroSample(pkg, named)
+
+	return &sample{Model: zero, Proto: zero}, nil
+}
+
+// converterSample samples a type converted by a converter, from the sample of the model it registers.
+func (g *generator) converterSample(pkg *Package, c *converter, t types.Type, name string, n int) (*sample, error) {
+	elemType := t
+	if pointer, ok := t.(*types.Pointer); ok {
+		elemType = pointer.Elem()
+	}
+
+	elem := &sample{Model: c.Sample}
+	if elem.Model == "" {
+		raw, err := g.typeSample(pkg, elemType, name, n)
+		if err != nil {
+			return nil, err
+		}
+
+		elem = raw
+	}
+
+	model := elem.Model
+	if _, ok := t.(*types.Pointer); ok {
+		model = pointerSample(g.testTypeName(pkg, elemType), elem.Model)
+	}
+
+	proto, err := executeSample(c.ProtoSample, model, elem.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sample{Model: model, Proto: proto}, nil
+}
+
+// zeroSample is the zero value of a type without sample, a composite literal for structs.
+func (g *generator) zeroSample(pkg *Package, t types.Type) string {
+	if named, ok := t.(*types.Named); ok {
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			return g.testTypeName(pkg, named) + "{}"
+		}
+	}
+
+	return g.zeroValue(t, g.testTypeName(pkg, t))
+}
+
+// hasCollectionSample reports whether the elements of a slice or a map of the type are sampled.
+func (g *generator) hasCollectionSample(pkg *Package, t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		return true
+	case *types.Pointer:
+		return g.model(pkg, t.Elem()) != nil
+	case *types.Named:
+		_, basic := t.Underlying().(*types.Basic)
+
+		return basic || g.hasEnumConverters(t)
+	}
+
+	return false
+}
+
+func basicSample(basic *types.Basic, name string, n int) *sample {
+	var value string
+
+	info := basic.Info()
+	switch {
+	case info&types.IsString != 0:
+		value = fmt.Sprintf("%q", name)
+	case info&types.IsBoolean != 0:
+		value = "true"
+	case info&types.IsNumeric != 0:
+		value = fmt.Sprint(n)
+	default:
+		value = "nil"
+	}
+
+	return &sample{Model: value, Proto: value}
+}
+
+// pointerSample takes the address of a sample, which can't be done on a literal.
+func pointerSample(typeName, value string) string {
+	return fmt.Sprintf("func() *%[1]s { var v %[1]s = %[2]s; return &v }()", typeName, value)
+}
+
+// enumSampleConstant is the first constant of the enum declared with a non zero value,
+// the zero value being the unset one in proto.
+func enumSampleConstant(named *types.Named) string {
+	scope := named.Obj().Pkg().Scope()
+
+	var first *types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) || constant.Sign(c.Val()) == 0 {
+			continue
+		}
+
+		if first == nil || c.Pos() < first.Pos() {
+			first = c
+		}
+	}
+
+	if first == nil {
+		return named.Obj().Name() + "(1)"
+	}
+
+	return first.Name()
+}
+
+// testTypeName is the name of the type in the tests, which are in the external test package.
+func (g *generator) testTypeName(pkg *Package, t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == pkg.Types {
+			return testPackage
+		}
+
+		return p.Name()
+	})
+}
+
+// testFunc qualifies a function or constant of the package of the named type for the tests.
+func (g *generator) testFunc(pkg *Package, named *types.Named, name string) string {
+	if named.Obj().Pkg() == pkg.Types {
+		return testPackage + "." + name
+	}
+
+	return named.Obj().Pkg().Name() + "." + name
+}
+
+// protoTypeName is the type protoc-gen-go generates for the elements of sampled slices and maps.
+func (g *generator) protoTypeName(pkg *Package, t types.Type) string {
+	switch t := t.(type) {
+	case *types.Pointer:
+		if named := g.model(pkg, t.Elem()); named != nil {
+			return "*replaceMe." + named.Obj().Name()
+		}
+	case *types.Named:
+		if g.hasEnumConverters(t) {
+			return "replaceMe." + t.Obj().Name()
+		}
+
+		if basic, ok := t.Underlying().(*types.Basic); ok {
+			return basic.Name()
+		}
+	}
+
+	return g.testTypeName(pkg, t)
+}
@@ -2,6 +2,7 @@ package accessor
 
 import (
 	"go/types"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -10,11 +11,53 @@ type Package struct {
 	*packages.Package
 	Dir     string
 	Structs []*Struct
+	Enums   []*Enum
 }
 
 type Struct struct {
-	Name   string
-	Fields []*Field
+	Name    string
+	Comment string
+	Fields  []*Field
+	// Tagged is whether a field has the accessor tag.
+	Tagged bool
+}
+
+// generateDirective marks a struct to generate without the accessor tag on any of its fields.
+const generateDirective = "//accessory:generate"
+
+// Targets returns the structs to generate when no type is given: the ones with the accessor tag
+// on a field, or the //accessory:generate directive in their doc comment.
+func (p *Package) Targets() []*Struct {
+	targets := make([]*Struct, 0)
+
+	for _, st := range p.Structs {
+		if st.Tagged || hasDirective(st.Comment) {
+			targets = append(targets, st)
+		}
+	}
+
+	return targets
+}
+
+// Lookup returns the struct of the package with the name, nil if there is none.
+func (p *Package) Lookup(name string) *Struct {
+	for _, st := range p.Structs {
+		if st.Name == name {
+			return st
+		}
+	}
+
+	return nil
+}
+
+func hasDirective(comment string) bool {
+	for _, line := range strings.Split(comment, "\n") {
+		if strings.TrimSpace(line) == generateDirective {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Example:
@@ -44,12 +87,49 @@ type Struct struct {
 //     Type: *github.com/zeals-co-ltd/zero-api/generated/go/entities/common.Card
 //     Tag: {Getter: "", Setter: nil}
 type Field struct {
-	Name string
-	Type types.Type
-	Tag  *Tag
+	Name     string
+	Type     types.Type
+	Tag      *Tag
+	Embedded bool
+}
+
+// Enum is a named integer type with constants of its own type declared in the package.
+//
+// Example:
+//
+//	// Status is the status of an order.
+//	type Status int32
+//
+//	const (
+//		StatusPending Status = iota + 1
+//		// StatusPaid is set once the payment is captured.
+//		StatusPaid
+//	)
+//
+// Enum will be:
+//
+//   - Name: Status
+//     Comment: // Status is the status of an order.
+//     Constants: {Name: StatusPending, Value: 1}, {Name: StatusPaid, Value: 2, Comment: // StatusPaid is ...}
+type Enum struct {
+	Name    string
+	Comment string
+	// Constants are in declaration order.
+	Constants []*Constant
+}
+
+type Constant struct {
+	Name    string
+	Value   int64
+	Comment string
 }
 
 type Tag struct {
 	Getter *string
 	Setter *string
+	// Oneof is the name of the proto oneof the field is a branch of, from accessor:"oneof=kind".
+	Oneof string
+	// Wrapper converts the field to its google.protobuf wrapper, from accessor:"wrapper".
+	// It's the only way to convert []byte to BytesValue, -wrappers converting the pointers only.
+	Wrapper bool
 }
@@ -3,7 +3,13 @@ package accessor
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"strings"
 
 	"github.com/spf13/afero"
 )
@@ -12,13 +18,18 @@ type writer struct {
 	buf        *bytes.Buffer
 	fs         afero.Fs
 	outputFile string
+	// sink receives the source instead of the output file when set, and the listing of a dry run.
+	sink   io.Writer
+	dryRun bool
 }
 
-func newWriter(fs afero.Fs, outputFile string) *writer {
+func newWriter(fs afero.Fs, outputFile string, sink io.Writer, dryRun bool) *writer {
 	return &writer{
 		buf:        new(bytes.Buffer),
 		fs:         fs,
 		outputFile: outputFile,
+		sink:       sink,
+		dryRun:     dryRun,
 	}
 }
 
@@ -35,6 +46,13 @@ func (w *writer) write(pkgName string, imports []string, accessors []string) err
 	if len(imports) > 0 {
 		w.printf("import (\n")
 		for i := range imports {
+			// The aliased imports are given as the name and the path separated by a space.
+			if name, importPath, ok := strings.Cut(imports[i], " "); ok {
+				w.printf("\t%s \"%s\"\n", name, importPath)
+
+				continue
+			}
+
 			w.printf("\t\"%s\"\n", imports[i])
 		}
 		w.printf(")\n")
@@ -49,9 +67,49 @@ func (w *writer) write(pkgName string, imports []string, accessors []string) err
 		return err
 	}
 
+	if w.dryRun {
+		return w.list(content)
+	}
+
+	if w.sink != nil {
+		_, err := w.sink.Write(content)
+
+		return err
+	}
+
 	return afero.WriteFile(w.fs, w.outputFile, content, 0644)
 }
 
+// list prints the output file and the functions and methods declared in the content to the sink.
+func (w *writer) list(content []byte) error {
+	file, err := parser.ParseFile(token.NewFileSet(), w.outputFile, content, parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+
+	// The listing is written at once, as other files may be listed concurrently.
+	listing := new(bytes.Buffer)
+	fmt.Fprintln(listing, w.outputFile)
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		name := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			name = fmt.Sprintf("(%s).%s", types.ExprString(fn.Recv.List[0].Type), name)
+		}
+
+		fmt.Fprintf(listing, "\t%s\n", name)
+	}
+
+	_, err = w.sink.Write(listing.Bytes())
+
+	return err
+}
+
 func (w *writer) format() ([]byte, error) {
 	// The error "'expected operand, found '=='" may occur if the receiver is empty
 	// fmt.Println(w.buf.String())
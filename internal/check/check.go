@@ -0,0 +1,166 @@
+// Package check compares freshly generated files with the ones on disk, for CI to fail on stale generated code.
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+// generatedMarker is in the header of every file accessory generates, a comment or the $comment of a JSON Schema.
+const generatedMarker = "Code generated by accessory"
+
+// headerLines is how many of the first lines of a file are searched for the marker.
+const headerLines = 5
+
+// Diff compares every file of generated, a file system the generation was run into, with the file at the same
+// path in disk. It prints a unified diff of each stale or missing file to w and reports whether there was any.
+// The files of disk matching the globs which were generated by accessory must be generated again,
+// the other ones are stale too and printed as removed.
+func Diff(disk, generated afero.Fs, w io.Writer, globs ...string) (bool, error) {
+	paths := make([]string, 0)
+	if err := afero.Walk(generated, string(os.PathSeparator), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			paths = append(paths, path)
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	extra, err := extraFiles(disk, generated, globs)
+	if err != nil {
+		return false, err
+	}
+
+	paths = append(paths, extra...)
+	sort.Strings(paths)
+
+	stale := false
+	for _, path := range paths {
+		toFile := path
+		want, err := afero.ReadFile(generated, path)
+		if os.IsNotExist(err) {
+			toFile = os.DevNull
+		} else if err != nil {
+			return false, err
+		}
+
+		fromFile := path
+		got, err := afero.ReadFile(disk, path)
+		if os.IsNotExist(err) {
+			fromFile = os.DevNull
+		} else if err != nil {
+			return false, err
+		}
+
+		if bytes.Equal(got, want) {
+			continue
+		}
+
+		stale = true
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(got),
+			B:        splitLines(want),
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  3,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		fmt.Fprint(w, diff)
+	}
+
+	return stale, nil
+}
+
+// extraFiles returns the files of disk matching the globs that accessory generated, but not this time.
+func extraFiles(disk, generated afero.Fs, globs []string) ([]string, error) {
+	extra := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, glob := range globs {
+		matches, err := afero.Glob(disk, glob)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range matches {
+			if seen[path] {
+				continue
+			}
+
+			seen[path] = true
+
+			info, err := disk.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+
+			if info.IsDir() {
+				continue
+			}
+
+			exists, err := afero.Exists(generated, path)
+			if err != nil {
+				return nil, err
+			}
+
+			if exists {
+				continue
+			}
+
+			content, err := afero.ReadFile(disk, path)
+			if err != nil {
+				return nil, err
+			}
+
+			if isGenerated(content) {
+				extra = append(extra, path)
+			}
+		}
+	}
+
+	return extra, nil
+}
+
+// isGenerated reports whether the header of the content has the marker of the files accessory generates.
+func isGenerated(content []byte) bool {
+	lines := strings.SplitN(string(content), "\n", headerLines+1)
+	if len(lines) > headerLines {
+		lines = lines[:headerLines]
+	}
+
+	for _, line := range lines {
+		if strings.Contains(line, generatedMarker) && strings.Contains(line, "DO NOT EDIT") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitLines splits the content after every newline, without the empty line
+// difflib.SplitLines adds after the last one.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
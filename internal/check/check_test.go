@@ -0,0 +1,93 @@
+package check_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/internal/check"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		disk      map[string]string
+		generated map[string]string
+		globs     []string
+		wantStale bool
+		wantDiff  string
+	}{
+		"UpToDate": {
+			disk:      map[string]string{"/src/a.go": "package a\n"},
+			generated: map[string]string{"/src/a.go": "package a\n"},
+		},
+		"Stale": {
+			disk: map[string]string{"/src/a.go": "package a\n\nvar x = 1\n"},
+			generated: map[string]string{
+				"/src/a.go": "package a\n\nvar x = 2\n",
+			},
+			wantStale: true,
+			wantDiff:  "--- /src/a.go\n+++ /src/a.go\n@@ -1,3 +1,3 @@\n package a\n \n-var x = 1\n+var x = 2\n",
+		},
+		"Missing": {
+			generated: map[string]string{"/src/b.go": "package b\n"},
+			wantStale: true,
+			wantDiff:  "--- /dev/null\n+++ /src/b.go\n@@ -0,0 +1 @@\n+package b\n",
+		},
+		"Extra": {
+			disk: map[string]string{
+				"/src/a_accessor.go":   "package a\n",
+				"/src/old_accessor.go": "// Code generated by accessory; DO NOT EDIT.\n",
+			},
+			generated: map[string]string{"/src/a_accessor.go": "package a\n"},
+			globs:     []string{"/src/*_accessor.go"},
+			wantStale: true,
+			wantDiff:  "--- /src/old_accessor.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-// Code generated by accessory; DO NOT EDIT.\n",
+		},
+		"ExtraNotGenerated": {
+			disk: map[string]string{
+				"/src/a_accessor.go":    "package a\n",
+				"/src/hand_accessor.go": "package a\n",
+			},
+			generated: map[string]string{"/src/a_accessor.go": "package a\n"},
+			globs:     []string{"/src/*_accessor.go"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			disk := afero.NewMemMapFs()
+			for path, content := range tt.disk {
+				if err := afero.WriteFile(disk, path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			generated := afero.NewMemMapFs()
+			for path, content := range tt.generated {
+				if err := afero.WriteFile(generated, path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			out := new(bytes.Buffer)
+			stale, err := check.Diff(disk, generated, out, tt.globs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stale != tt.wantStale {
+				t.Errorf("stale = %v, want %v", stale, tt.wantStale)
+			}
+
+			if out.String() != tt.wantDiff {
+				t.Errorf("diff = %q, want %q", out.String(), tt.wantDiff)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package enum
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// LoadDescriptorSet reads a binary FileDescriptorSet, as written by protoc -o set.pb --include_source_info.
+// Only the files named in names are returned, or every file of the set apart from the well-known types
+// if names is empty, sorted by path.
+func LoadDescriptorSet(setPath string, names []string) ([]*ProtoFile, error) {
+	content, err := os.ReadFile(setPath)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(content, set); err != nil {
+		return nil, fmt.Errorf("failed to read the FileDescriptorSet %s: %w", setPath, err)
+	}
+
+	if len(names) == 0 {
+		for _, fd := range set.GetFile() {
+			if !strings.HasPrefix(fd.GetName(), wellKnownImportPrefix) {
+				names = append(names, fd.GetName())
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return protoFilesFromDescriptors(set.GetFile(), names)
+}
+
+// protoFilesFromDescriptors converts the enums and the messages of the files named in names,
+// all the files they import must be among the descriptors unless they are well-known types.
+func protoFilesFromDescriptors(descriptors []*descriptorpb.FileDescriptorProto, names []string) ([]*ProtoFile, error) {
+	registry, err := protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(&descriptorpb.FileDescriptorSet{
+		File: descriptors,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ProtoFile, 0, len(names))
+
+	for _, name := range names {
+		fd, err := registry.FindFileByPath(name)
+		if err == protoregistry.NotFound {
+			return nil, fmt.Errorf("%s is to be generated but its descriptor is missing", name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := protoFileFromDescriptor(fd)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// protoFileFromDescriptor converts the enums and the messages of a compiled proto file, nested ones included,
+// so the output is the same as with the proto file parsed from text.
+func protoFileFromDescriptor(fd protoreflect.FileDescriptor) (*ProtoFile, error) {
+	file := &ProtoFile{
+		Path:    fd.Path(),
+		Package: string(fd.Package()),
+	}
+
+	protoPackage := goPackageName(fd)
+
+	var addEnums func(enums protoreflect.EnumDescriptors, parent *Message) error
+	addEnums = func(enums protoreflect.EnumDescriptors, parent *Message) error {
+		for i := 0; i < enums.Len(); i++ {
+			enum, err := enumFromDescriptor(enums.Get(i), parent)
+			if err != nil {
+				return fmt.Errorf("%s: %w", fd.Path(), err)
+			}
+
+			enum.ProtoPackage = protoPackage
+			file.Enums = append(file.Enums, enum)
+		}
+
+		return nil
+	}
+
+	var addMessages func(messages protoreflect.MessageDescriptors, parent *Message) error
+	addMessages = func(messages protoreflect.MessageDescriptors, parent *Message) error {
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			// Map entries are synthetic messages, they can't hold enums.
+			if md.IsMapEntry() {
+				continue
+			}
+
+			message, err := messageFromDescriptor(md, parent)
+			if err != nil {
+				return fmt.Errorf("%s: %w", fd.Path(), err)
+			}
+
+			file.Messages = append(file.Messages, message)
+
+			if err := addEnums(md.Enums(), message); err != nil {
+				return err
+			}
+
+			if err := addMessages(md.Messages(), message); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := addEnums(fd.Enums(), nil); err != nil {
+		return nil, err
+	}
+
+	if err := addMessages(fd.Messages(), nil); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// enumFromDescriptor converts the enum, parent is the message it's nested in, nil for top level enums.
+func enumFromDescriptor(ed protoreflect.EnumDescriptor, parent *Message) (*Enum, error) {
+	options, _ := ed.Options().(*descriptorpb.EnumOptions)
+
+	enum := &Enum{
+		Title:      string(ed.Name()),
+		AllowAlias: options.GetAllowAlias(),
+		Comment:    formatComment(ed.ParentFile().SourceLocations().ByDescriptor(ed).LeadingComments),
+	}
+
+	if parent != nil {
+		// protoc-gen-go names nested enums after their message, and prefixes their values with it.
+		enum.Title = parent.Title + enum.Title
+		enum.ProtoName = parent.ProtoName + "_" + string(ed.Name())
+		enum.ProtoValuePrefix = parent.ProtoName
+		enum.FullName = parent.FullName + "." + string(ed.Name())
+	}
+
+	values := make([]*Value, 0, ed.Values().Len())
+	for i := 0; i < ed.Values().Len(); i++ {
+		vd := ed.Values().Get(i)
+		valueOptions, _ := vd.Options().(*descriptorpb.EnumValueOptions)
+
+		values = append(values, &Value{
+			OriginalStringValue: string(vd.Name()),
+			StringValue:         convertSnekToPascalCase(string(vd.Name())),
+			NumberValue:         int(vd.Number()),
+			Comment:             formatComment(ed.ParentFile().SourceLocations().ByDescriptor(vd).LeadingComments),
+			Deprecated:          valueOptions.GetDeprecated(),
+		})
+	}
+
+	scopeValues(values, parent)
+
+	if err := setValues(enum, values); err != nil {
+		return nil, err
+	}
+
+	return enum, nil
+}
+
+// messageFromDescriptor converts the message and its fields, parent is the message it's nested in,
+// nil for top level messages.
+func messageFromDescriptor(md protoreflect.MessageDescriptor, parent *Message) (*Message, error) {
+	message := &Message{
+		Title:     string(md.Name()),
+		ProtoName: string(md.Name()),
+		FullName:  string(md.Name()),
+		Comment:   formatComment(md.ParentFile().SourceLocations().ByDescriptor(md).LeadingComments),
+	}
+
+	if parent != nil {
+		message.Title = parent.Title + message.Title
+		message.ProtoName = parent.ProtoName + "_" + message.ProtoName
+		message.FullName = parent.FullName + "." + message.FullName
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field, err := fieldFromDescriptor(fields.Get(i))
+		if err != nil {
+			return nil, fmt.Errorf("message %s, field %s: %w", message.FullName, fields.Get(i).Name(), err)
+		}
+
+		message.Fields = append(message.Fields, field)
+	}
+
+	return message, nil
+}
+
+// fieldFromDescriptor converts the field, with the Go type resolveFieldTypes gives to the same field in text.
+func fieldFromDescriptor(fd protoreflect.FieldDescriptor) (*Field, error) {
+	field := &Field{
+		OriginalName: string(fd.Name()),
+		Name:         convertSnekToCamelCase(string(fd.Name())),
+		Number:       int(fd.Number()),
+		Comment:      formatComment(fd.ParentFile().SourceLocations().ByDescriptor(fd).LeadingComments),
+	}
+
+	// proto3 optional fields are in a synthetic oneof, they are optional rather than part of a oneof.
+	if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+		field.Oneof = string(oneof.Name())
+	}
+
+	if fd.IsMap() {
+		keyType, ok := scalarTypes[fd.MapKey().Kind().String()]
+		if !ok {
+			return nil, fmt.Errorf("map key type %s is not a scalar", fd.MapKey().Kind())
+		}
+
+		valueType, err := descriptorValueType(fd.MapValue())
+		if err != nil {
+			return nil, err
+		}
+
+		field.MapKey = fd.MapKey().Kind().String()
+		field.ProtoType = descriptorTypeName(fd.MapValue())
+		field.GoType = fmt.Sprintf("map[%s]%s", keyType, valueType)
+
+		return field, nil
+	}
+
+	valueType, err := descriptorValueType(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	field.ProtoType = descriptorTypeName(fd)
+
+	switch {
+	case fd.Cardinality() == protoreflect.Repeated:
+		field.Label = labelRepeated
+		field.GoType = "[]" + valueType
+	case fd.HasOptionalKeyword():
+		field.Label = labelOptional
+		field.GoType = pointerTo(valueType)
+	case field.Oneof != "":
+		field.GoType = pointerTo(valueType)
+	default:
+		field.GoType = valueType
+	}
+
+	return field, nil
+}
+
+// descriptorValueType returns the Go type of a single value of the field, messages are pointers to their models.
+func descriptorValueType(fd protoreflect.FieldDescriptor) (string, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		name := string(fd.Message().FullName())
+		if goType, ok := wellKnownTypes[name]; ok {
+			return goType, nil
+		}
+
+		if strings.HasPrefix(name, "google.protobuf.") {
+			return "", fmt.Errorf("well-known type %s is not supported", name)
+		}
+
+		title, err := descriptorTitle(fd.Message())
+		if err != nil {
+			return "", err
+		}
+
+		return "*" + title, nil
+	case protoreflect.EnumKind:
+		return descriptorTitle(fd.Enum())
+	}
+
+	goType, ok := scalarTypes[fd.Kind().String()]
+	if !ok {
+		return "", fmt.Errorf("unsupported type %s", fd.Kind())
+	}
+
+	return goType, nil
+}
+
+// descriptorTypeName is the type of the field as written in proto, fully qualified for messages and enums.
+func descriptorTypeName(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(fd.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(fd.Enum().FullName())
+	}
+
+	return fd.Kind().String()
+}
+
+// descriptorTitle is the name of the model of a message or an enum, nested ones being prefixed
+// with their parents: Schedule.Frequency => ScheduleFrequency.
+func descriptorTitle(d protoreflect.Descriptor) (string, error) {
+	if d.IsPlaceholder() {
+		return "", fmt.Errorf("unresolved type %s", d.FullName())
+	}
+
+	name := strings.TrimPrefix(string(d.FullName()), string(d.ParentFile().Package())+".")
+
+	return strings.ReplaceAll(name, ".", ""), nil
+}
+
+// goPackageName returns the name of the Go package protoc-gen-go generates the file into,
+// empty if the file has no go_package option.
+//
+//	example.com/delivery/v1;deliveryv1 => deliveryv1
+//	example.com/delivery/v1            => v1
+func goPackageName(fd protoreflect.FileDescriptor) string {
+	options, _ := fd.Options().(*descriptorpb.FileOptions)
+
+	return goPackageNameOf(options.GetGoPackage())
+}
+
+// goPackageNameOf returns the package name of a go_package option value.
+func goPackageNameOf(goPackage string) string {
+	if goPackage == "" {
+		return ""
+	}
+
+	if _, name, ok := strings.Cut(goPackage, ";"); ok {
+		return name
+	}
+
+	return strings.NewReplacer("-", "_", ".", "_").Replace(path.Base(goPackage))
+}
+
+// formatComment turns a comment from the source info into "//" lines, like the ones extractValues collects.
+func formatComment(comment string) string {
+	comment = strings.TrimSuffix(comment, "\n")
+	if strings.TrimSpace(comment) == "" {
+		return ""
+	}
+
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("//"+line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
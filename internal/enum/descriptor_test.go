@@ -0,0 +1,67 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+// testdata/descriptor/schedule.pb is protoc -o schedule.pb --include_source_info schedule.proto
+func TestLoadDescriptorSet(t *testing.T) {
+	t.Parallel()
+
+	files, err := enum.LoadDescriptorSet("testdata/descriptor/schedule.pb", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := enum.GenerateFiles(files, &enum.Options{
+		NameStyle: enum.NameStyleProto,
+		Layout:    enum.LayoutFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshotOutputs(t, outputs)
+}
+
+// The proto text and the compiled descriptor of the same file generate the same code.
+func TestLoadProtoFiles_SameAsDescriptorSet(t *testing.T) {
+	t.Parallel()
+
+	text, err := enum.LoadProtoFiles([]string{"testdata/descriptor/schedule.proto"}, []string{"testdata/descriptor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	descriptor, err := enum.LoadDescriptorSet("testdata/descriptor/schedule.pb", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The text has no go_package option to name the proto package after.
+	opts := &enum.Options{
+		NameStyle:    enum.NameStyleProto,
+		Layout:       enum.LayoutFile,
+		ProtoPackage: "schedulev1",
+	}
+
+	got, err := enum.GenerateFiles(text, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := enum.GenerateFiles(descriptor, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 || len(want) != 1 {
+		t.Fatalf("got %d files from the text and %d from the descriptor, want 1", len(got), len(want))
+	}
+
+	if string(got[0].Content) != string(want[0].Content) {
+		t.Errorf("the text generates\n%s\nthe descriptor generates\n%s", got[0].Content, want[0].Content)
+	}
+}
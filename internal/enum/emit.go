@@ -0,0 +1,318 @@
+package enum
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Backends that can be requested with -emit.
+const (
+	// EmitGo generates the Go enums, conversions and model structs.
+	EmitGo = "go"
+	// EmitTypeScript generates a TypeScript enum and union type per enum.
+	EmitTypeScript = "ts"
+	// EmitJSONSchema generates a JSON Schema enum definition per enum.
+	EmitJSONSchema = "jsonschema"
+	// EmitSQL generates the Postgres enum types, and a migration when the previous DDL is given.
+	EmitSQL = "sql"
+)
+
+// jsonSchemaDraft is the dialect of the generated schemas.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Emitters is the set of backends to generate the enums with.
+type Emitters map[string]bool
+
+// ParseEmitters parses the comma separated -emit value, e.g. "go,ts".
+func ParseEmitters(input string) (Emitters, error) {
+	emitters := make(Emitters)
+
+	for _, emitter := range strings.Split(input, ",") {
+		emitter = strings.TrimSpace(emitter)
+
+		switch emitter {
+		case "":
+			continue
+		case EmitGo, EmitTypeScript, EmitJSONSchema, EmitSQL:
+			emitters[emitter] = true
+		default:
+			return nil, fmt.Errorf("unknown emitter %q, expected one of: %s",
+				emitter, strings.Join([]string{EmitGo, EmitTypeScript, EmitJSONSchema, EmitSQL}, ","))
+		}
+	}
+
+	if len(emitters) == 0 {
+		return nil, fmt.Errorf("no emitter given, expected at least one of: %s",
+			strings.Join([]string{EmitGo, EmitTypeScript, EmitJSONSchema, EmitSQL}, ","))
+	}
+
+	return emitters, nil
+}
+
+// String returns the emitters in a deterministic order.
+func (e Emitters) String() string {
+	emitters := make([]string, 0, len(e))
+	for emitter := range e {
+		emitters = append(emitters, emitter)
+	}
+
+	sort.Strings(emitters)
+
+	return strings.Join(emitters, ",")
+}
+
+// generateTypeScriptFiles generates the TypeScript enums with the layout of the options.
+func generateTypeScriptFiles(files []*ProtoFile, opts *Options) []*OutputFile {
+	outputs := make([]*OutputFile, 0)
+
+	for _, file := range files {
+		if opts.Layout == LayoutFile {
+			if len(file.GetEnums()) == 0 {
+				continue
+			}
+
+			result := typeScriptHeader(file)
+			for _, enum := range file.GetEnums() {
+				result = result + "\n" + enum.ToTypeScript(opts.NameStyle, opts.jsonNames(enum))
+			}
+
+			outputs = append(outputs, &OutputFile{Name: file.outputBase() + ".ts", Content: []byte(result)})
+
+			continue
+		}
+
+		for _, enum := range file.GetEnums() {
+			result := typeScriptHeader(file) + "\n" + enum.ToTypeScript(opts.NameStyle, opts.jsonNames(enum))
+
+			outputs = append(outputs, &OutputFile{Name: enum.GetTitle() + ".ts", Content: []byte(result)})
+		}
+	}
+
+	return outputs
+}
+
+func typeScriptHeader(file *ProtoFile) string {
+	return fmt.Sprintf("// Code generated by accessory; DO NOT EDIT.\n// source: %s\n", file.GetPath())
+}
+
+// ToTypeScript generates an enum holding what the JSON of the Enum is made of, the names if
+// the Go enum marshals them and the numbers otherwise, and the union type of these for the code
+// that doesn't want the enum.
+//
+//	export enum ReminderState {
+//	  Started = "REMINDER_STATE_STARTED",
+//	}
+//
+//	export type ReminderStateName = "REMINDER_STATE_STARTED";
+//
+// The union type of the numbers is <Enum>Number.
+func (e *Enum) ToTypeScript(nameStyle string, names bool) string {
+	if e == nil {
+		return ""
+	}
+
+	result := jsDoc(e.GetComment(), false, "")
+
+	literals := make([]string, 0, len(e.GetValues()))
+	members := ""
+
+	for _, value := range e.GetValues() {
+		if value.IsAlias() {
+			continue
+		}
+
+		literal := strconv.Itoa(value.GetNumberValue())
+		if names {
+			literal = strconv.Quote(value.GetName(nameStyle))
+		}
+
+		literals = append(literals, literal)
+		members = members + jsDoc(value.GetComment(), value.GetDeprecated(), "  ") +
+			fmt.Sprintf("  %s = %s,\n", value.typeScriptMember(e.GetTitle()), literal)
+	}
+
+	union := "Number"
+	if names {
+		union = "Name"
+	}
+
+	return result + fmt.Sprintf(`export enum %s {
+%s}
+
+export type %s%s = %s;
+`, e.GetTitle(), members, e.GetTitle(), union, strings.Join(literals, " | "))
+}
+
+// typeScriptMember is the Go name of the Value without the enum prefix, the full Go name
+// if nothing usable is left once the prefix is removed.
+func (v *Value) typeScriptMember(enumTitle string) string {
+	member := strings.TrimPrefix(v.GetStringValue(), enumTitle)
+	if member == "" || !unicode.IsLetter([]rune(member)[0]) {
+		return v.GetStringValue()
+	}
+
+	return member
+}
+
+// jsDoc turns a "//" comment into a JSDoc block with the given indentation, empty if there is nothing to say.
+func jsDoc(comment string, deprecated bool, indent string) string {
+	lines := commentLines(comment)
+	if deprecated {
+		lines = append(lines, "@deprecated")
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	if len(lines) == 1 {
+		return fmt.Sprintf("%s/** %s */\n", indent, lines[0])
+	}
+
+	result := indent + "/**\n"
+	for _, line := range lines {
+		result = result + strings.TrimRight(indent+" * "+line, " ") + "\n"
+	}
+
+	return result + indent + " */\n"
+}
+
+// commentLines strips the "//" markers of a comment.
+func commentLines(comment string) []string {
+	if strings.TrimSpace(comment) == "" {
+		return nil
+	}
+
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "//")
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+
+	return lines
+}
+
+// jsonSchema is the subset of JSON Schema the enums need, the fields are in the order they are written.
+type jsonSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Comment     string                 `json:"$comment,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Enum        []interface{}          `json:"enum,omitempty"`
+	OneOf       []*jsonSchemaConst     `json:"oneOf,omitempty"`
+	Defs        map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// jsonSchemaConst documents a single value, as enum can't hold descriptions.
+// Const is the name or the number of the value.
+type jsonSchemaConst struct {
+	Const       interface{} `json:"const"`
+	Description string      `json:"description,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+}
+
+// generateJSONSchemaFiles generates the JSON Schemas with the layout of the options,
+// the file layout puts every enum of a proto file in the $defs of a single schema.
+func generateJSONSchemaFiles(files []*ProtoFile, opts *Options) ([]*OutputFile, error) {
+	outputs := make([]*OutputFile, 0)
+
+	for _, file := range files {
+		if opts.Layout == LayoutFile {
+			if len(file.GetEnums()) == 0 {
+				continue
+			}
+
+			schema := &jsonSchema{
+				Schema:  jsonSchemaDraft,
+				Comment: jsonSchemaComment(file),
+				Title:   file.GetPath(),
+				Defs:    make(map[string]*jsonSchema),
+			}
+
+			for _, enum := range file.GetEnums() {
+				schema.Defs[enum.GetTitle()] = enum.toJSONSchema(opts.NameStyle, opts.jsonNames(enum))
+			}
+
+			content, err := marshalJSONSchema(schema)
+			if err != nil {
+				return nil, err
+			}
+
+			outputs = append(outputs, &OutputFile{Name: file.outputBase() + ".schema.json", Content: content})
+
+			continue
+		}
+
+		for _, enum := range file.GetEnums() {
+			schema := enum.toJSONSchema(opts.NameStyle, opts.jsonNames(enum))
+			schema.Schema = jsonSchemaDraft
+			schema.Comment = jsonSchemaComment(file)
+
+			content, err := marshalJSONSchema(schema)
+			if err != nil {
+				return nil, err
+			}
+
+			outputs = append(outputs, &OutputFile{Name: enum.GetTitle() + ".schema.json", Content: content})
+		}
+	}
+
+	return outputs, nil
+}
+
+// jsonSchemaComment is the header of the schemas, as JSON has no comments.
+func jsonSchemaComment(file *ProtoFile) string {
+	return fmt.Sprintf("Code generated by accessory; DO NOT EDIT. source: %s", file.GetPath())
+}
+
+// toJSONSchema generates the definition of what the JSON of the Enum is made of,
+// the names if the Go enum marshals them and the numbers otherwise.
+func (e *Enum) toJSONSchema(nameStyle string, names bool) *jsonSchema {
+	if e == nil {
+		return nil
+	}
+
+	schema := &jsonSchema{
+		Title:       e.GetTitle(),
+		Description: strings.Join(commentLines(e.GetComment()), "\n"),
+		Type:        "integer",
+	}
+
+	if names {
+		schema.Type = "string"
+	}
+
+	for _, value := range e.GetValues() {
+		if value.IsAlias() {
+			continue
+		}
+
+		var constant interface{} = value.GetNumberValue()
+		if names {
+			constant = value.GetName(nameStyle)
+		}
+
+		schema.Enum = append(schema.Enum, constant)
+		schema.OneOf = append(schema.OneOf, &jsonSchemaConst{
+			Const:       constant,
+			Description: strings.Join(commentLines(value.GetComment()), "\n"),
+			Deprecated:  value.GetDeprecated(),
+		})
+	}
+
+	return schema
+}
+
+func marshalJSONSchema(schema *jsonSchema) ([]byte, error) {
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
@@ -0,0 +1,89 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateFiles_Emit(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		emit      string
+		with      string
+		layout    string
+		nameStyle string
+	}{
+		"TypeScript": {
+			emit:      "ts",
+			with:      "json",
+			layout:    enum.LayoutEnum,
+			nameStyle: enum.NameStyleProto,
+		},
+		"JSONSchema": {
+			emit:      "jsonschema",
+			with:      "json",
+			layout:    enum.LayoutEnum,
+			nameStyle: enum.NameStyleLower,
+		},
+		"FileLayout": {
+			emit:      "ts,jsonschema",
+			with:      "json",
+			layout:    enum.LayoutFile,
+			nameStyle: enum.NameStyleProto,
+		},
+		"Numbers": {
+			emit:   "ts,jsonschema",
+			layout: enum.LayoutFile,
+		},
+		"TextNames": {
+			emit:      "ts,jsonschema",
+			with:      "text",
+			layout:    enum.LayoutFile,
+			nameStyle: enum.NameStyleLower,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			emitters, err := enum.ParseEmitters(tt.emit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			families, err := enum.ParseMethodFamilies(tt.with)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files, err := enum.LoadProtoFiles([]string{"testdata/text"}, []string{"testdata/text"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				Families:  families,
+				NameStyle: tt.nameStyle,
+				Layout:    tt.layout,
+				Emit:      emitters,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
+
+func TestParseEmitters_Unknown(t *testing.T) {
+	t.Parallel()
+
+	if _, err := enum.ParseEmitters("go,swift"); err == nil {
+		t.Fatal("expected an error for the unknown emitter")
+	}
+}
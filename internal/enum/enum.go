@@ -0,0 +1,700 @@
+package enum
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+var (
+	// caser is for converting string to UpperCase title.
+	caser = cases.Title(language.AmericanEnglish)
+)
+
+const (
+	// DefaultProtoPackage is the Go package the generated code refers to the proto enums with.
+	DefaultProtoPackage = "delivery_settings_entities"
+	// DefaultModelPackage is the Go package the generated tests refer to the model enums with.
+	DefaultModelPackage = "models"
+	// DefaultOutputPackage is the package clause of the generated files.
+	DefaultOutputPackage = "input_enum"
+)
+
+type Enum struct {
+	Title      string
+	Receiver   string
+	AllowAlias bool
+	Values     []*Value
+	// DefaultValue is the first value declared in the proto enum, which is
+	// what proto falls back to for unknown numbers.
+	DefaultValue *Value
+	// ProtoPackage is the Go package of the proto enum, DefaultProtoPackage if empty.
+	ProtoPackage string
+	// ModelPackage is the Go package of the model enum, DefaultModelPackage if empty.
+	ModelPackage string
+	// ProtoName is the name protoc-gen-go gives to the enum, Title if empty.
+	// Enums nested in a message are prefixed with it: Reminder_Kind.
+	ProtoName string
+	// ProtoValuePrefix prefixes the values in the proto package, Title if empty.
+	// Values of enums nested in a message are prefixed with the message instead: Reminder_KIND_DAILY.
+	ProtoValuePrefix string
+	// FullName is the name of the enum relative to the proto package, Title if empty.
+	// Enums nested in a message are qualified with it: Reminder.Kind.
+	FullName string
+	Comment  string
+	// Flags makes the enum a set of bit flags, converted to and from a repeated proto enum field.
+	Flags bool
+}
+
+// GetTitle returns the Enum's Title.
+func (e *Enum) GetTitle() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.Title
+}
+
+// GetTitle returns the Enum's Title.
+func (e *Enum) GetReceiver() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.Receiver == "" {
+		e.Receiver = string(strings.ToLower(e.Title)[0])
+	}
+
+	return e.Receiver
+}
+
+// GetValues returns the Enum's Values.
+func (e *Enum) GetValues() []*Value {
+	if e == nil {
+		return nil
+	}
+
+	return e.Values
+}
+
+// GetProtoPackage returns the Enum's ProtoPackage.
+func (e *Enum) GetProtoPackage() string {
+	if e == nil || e.ProtoPackage == "" {
+		return DefaultProtoPackage
+	}
+
+	return e.ProtoPackage
+}
+
+// GetModelPackage returns the Enum's ModelPackage.
+func (e *Enum) GetModelPackage() string {
+	if e == nil || e.ModelPackage == "" {
+		return DefaultModelPackage
+	}
+
+	return e.ModelPackage
+}
+
+// GetProtoName returns the Enum's ProtoName.
+func (e *Enum) GetProtoName() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.ProtoName == "" {
+		return e.Title
+	}
+
+	return e.ProtoName
+}
+
+// GetProtoValuePrefix returns the Enum's ProtoValuePrefix.
+func (e *Enum) GetProtoValuePrefix() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.ProtoValuePrefix == "" {
+		return e.Title
+	}
+
+	return e.ProtoValuePrefix
+}
+
+// GetFullName returns the Enum's FullName.
+func (e *Enum) GetFullName() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.FullName == "" {
+		return e.Title
+	}
+
+	return e.FullName
+}
+
+// GetComment returns the Enum's Comment.
+func (e *Enum) GetComment() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.Comment
+}
+
+// GetAllowAlias returns the Enum's AllowAlias.
+func (e *Enum) GetAllowAlias() bool {
+	if e == nil {
+		return false
+	}
+
+	return e.AllowAlias
+}
+
+// GetFlags returns the Enum's Flags.
+func (e *Enum) GetFlags() bool {
+	if e == nil {
+		return false
+	}
+
+	return e.Flags
+}
+
+// GetDefaultValue returns the Enum's DefaultValue.
+func (e *Enum) GetDefaultValue() *Value {
+	if e == nil {
+		return nil
+	}
+
+	return e.DefaultValue
+}
+
+func (e *Enum) ToString() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.Comment == "" {
+		return fmt.Sprintf(`
+type %s int32
+
+const (
+	%s)`, e.Title, ConvertValuesToStruct(e.Values, e.Title))
+	}
+
+	return fmt.Sprintf(`
+%s
+type %s int32
+
+const (
+	%s)`, e.Comment, e.Title, ConvertValuesToStruct(e.Values, e.Title))
+}
+
+func (e *Enum) ToProto() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+// ToProto converts the %s to Protobuf version.
+func (%s %s) ToProto() %s.%s {
+	switch %s {%s
+	default:
+		return %s.%s_%s
+	}
+}`, e.Title, e.GetReceiver(), e.Title, e.GetProtoPackage(), e.GetProtoName(), e.GetReceiver(), ConvertValuesToProtos(e),
+		e.GetProtoPackage(), e.GetProtoValuePrefix(), e.GetDefaultValue().GetOriginalStringValue())
+}
+
+func (e *Enum) ProtoToEnum() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+// ProtoTo%s converts from Protobuf version to the %s.
+func ProtoTo%s(%s %s.%s) %s {
+	switch %s {%s
+	default:
+		return %s
+	}
+}`, e.Title, e.Title, e.Title, e.GetReceiver(), e.GetProtoPackage(), e.GetProtoName(), e.Title, e.GetReceiver(), ConvertValuesToProtoToEnum(e),
+		e.GetDefaultValue().GetStringValue())
+}
+
+// GenerateTest generates the round trip test of every value in the test style.
+func (e *Enum) GenerateTest(style string) (string, error) {
+	return e.roundTripTest(style, fmt.Sprintf("%s.%s", e.GetProtoPackage(), e.GetProtoName()), AssembleTestData(e))
+}
+
+// roundTripTest generates a test converting the args of every case to proto and back.
+func (e *Enum) roundTripTest(style, protoType string, cases []*testgen.Case) (string, error) {
+	data := testgen.Data(style)
+
+	return testgen.Render(style, &testgen.Test{
+		Name:        e.GetTitle() + "_Convert",
+		Description: "Convert from model to Proto and then convert back to model",
+		Want:        fmt.Sprintf("args %s.%s\nwantProto %s", e.GetModelPackage(), e.GetTitle(), protoType),
+		Cases:       cases,
+		Body: fmt.Sprintf(`// Convert from model to Proto.
+			gotProto := %s.args.ToProto()
+			%s
+
+			// Then convert from Proto back to model
+			gotModel := %s.ProtoTo%s(gotProto)
+			%s`,
+			data, testgen.Equal(style, data+".wantProto", "gotProto"),
+			e.GetModelPackage(), e.Title, testgen.Equal(style, data+".args", "gotModel")),
+	})
+}
+
+type Value struct {
+	OriginalStringValue string
+	StringValue         string
+	NumberValue         int
+	Comment             string
+	// AliasOf is the first value declared with the same number,
+	// it's only set when the enum has "option allow_alias = true;".
+	AliasOf *Value
+	// Deprecated is set by "[deprecated = true]".
+	Deprecated bool
+}
+
+// GetOriginalStringValue returns the Value's OriginalStringValue.
+func (v *Value) GetOriginalStringValue() string {
+	if v == nil {
+		return ""
+	}
+
+	return v.OriginalStringValue
+}
+
+// GetStringValue returns the Value's StringValue.
+func (v *Value) GetStringValue() string {
+	if v == nil {
+		return ""
+	}
+
+	return v.StringValue
+}
+
+// GetNumberValue returns the Value's IntValue.
+func (v *Value) GetNumberValue() int {
+	if v == nil {
+		return 0
+	}
+
+	return v.NumberValue
+}
+
+// GetComment returns the Value's Comment.
+func (v *Value) GetComment() string {
+	if v == nil {
+		return ""
+	}
+
+	return v.Comment
+}
+
+// GetDeprecated returns the Value's Deprecated.
+func (v *Value) GetDeprecated() bool {
+	if v == nil {
+		return false
+	}
+
+	return v.Deprecated
+}
+
+// GetAliasOf returns the Value's AliasOf.
+func (v *Value) GetAliasOf() *Value {
+	if v == nil {
+		return nil
+	}
+
+	return v.AliasOf
+}
+
+// IsAlias reports whether the Value shares its number with a previously declared Value.
+func (v *Value) IsAlias() bool {
+	return v.GetAliasOf() != nil
+}
+
+func (v *Value) ToStruct(enumTitle string) string {
+	if v == nil {
+		return ""
+	}
+
+	comment := v.Comment
+	if v.Deprecated {
+		// A "Deprecated:" paragraph is what staticcheck and gopls look for.
+		deprecation := fmt.Sprintf("// Deprecated: %s is deprecated in the proto definition.", v.OriginalStringValue)
+		if comment == "" {
+			comment = deprecation
+		} else {
+			comment = comment + "\n//\n" + deprecation
+		}
+	}
+
+	if comment == "" {
+		return fmt.Sprintf(`%s %s = %d
+`, v.StringValue, enumTitle, v.NumberValue)
+	}
+
+	return fmt.Sprintf(`%s
+	%s %s = %d
+`, comment, v.StringValue, enumTitle, v.NumberValue)
+}
+
+func (v *Value) ToProto(e *Enum) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+	case %s:
+		return %s.%s_%s`, v.StringValue, e.GetProtoPackage(), e.GetProtoValuePrefix(), v.OriginalStringValue)
+}
+
+func (v *Value) ProtoToEnum(e *Enum) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+	case %s.%s_%s:
+		return %s`, e.GetProtoPackage(), e.GetProtoValuePrefix(), v.OriginalStringValue, v.StringValue)
+}
+
+func (v *Value) ToTestData(e *Enum) *testgen.Case {
+	if v == nil {
+		return nil
+	}
+
+	return &testgen.Case{
+		Name: fmt.Sprintf("given %s value", v.StringValue),
+		Data: fmt.Sprintf("args: %s.%s,\nwantProto: %s.%s_%s,",
+			e.GetModelPackage(), v.StringValue, e.GetProtoPackage(), e.GetProtoValuePrefix(), v.OriginalStringValue),
+	}
+}
+
+// ConvertValuesToProtos builds one case per value, aliases are skipped
+// because they would be duplicated cases of the value they alias.
+func ConvertValuesToProtos(e *Enum) string {
+	result := ""
+
+	for _, value := range e.GetValues() {
+		if value.IsAlias() {
+			continue
+		}
+
+		result = result + value.ToProto(e)
+	}
+
+	return result
+}
+
+// ConvertValuesToProtoToEnum builds one case per value, aliases are skipped
+// because they would be duplicated cases of the value they alias.
+func ConvertValuesToProtoToEnum(e *Enum) string {
+	result := ""
+
+	for _, value := range e.GetValues() {
+		if value.IsAlias() {
+			continue
+		}
+
+		result = result + value.ProtoToEnum(e)
+	}
+
+	return result
+}
+
+func ConvertValuesToStruct(values []*Value, enumTitle string) string {
+	result := ""
+
+	for _, value := range values {
+		result = result + value.ToStruct(enumTitle)
+	}
+
+	return result
+}
+
+func AssembleTestData(e *Enum) []*testgen.Case {
+	// Aliases convert back to the value they alias, so they can't make a round trip on their own.
+	cases := make([]*testgen.Case, 0, len(e.GetValues()))
+	for _, value := range e.GetValues() {
+		if !value.IsAlias() {
+			cases = append(cases, value.ToTestData(e))
+		}
+	}
+
+	return cases
+}
+
+var (
+	allowAliasRegex = regexp.MustCompile(`option\s+allow_alias\s*=\s*true`)
+	flagsRegex      = regexp.MustCompile(`option\s+` + regexp.QuoteMeta(FlagsOption) + `\s*=\s*true`)
+	// intLitRegex is the intLit of the proto grammar, with the sign enum values may have:
+	// a decimal (31), hex (0x1F) or octal (037) literal, no 0b, 0o or "_" like Go allows.
+	intLitRegex = regexp.MustCompile(`^-?(0[xX][0-9a-fA-F]+|0[0-7]*|[1-9][0-9]*)$`)
+)
+
+// extractEnum takes the content of the file, and converts its top level enums to a bunch of Enum.
+// The enums nested in messages are extracted with the messages, see extractMessage.
+func extractEnum(input string) ([]*Enum, error) {
+	items, err := scanProtoItems(input)
+	if err != nil {
+		return nil, err
+	}
+
+	enums := make([]*Enum, 0)
+
+	for _, item := range items {
+		if item.Kind != "enum" {
+			continue
+		}
+
+		enum, err := extractEnumItem(item, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		enums = append(enums, enum)
+	}
+
+	return enums, nil
+}
+
+// extractEnumItem converts the enum item, parent is the message it's nested in, nil for top level enums.
+func extractEnumItem(item *protoItem, parent *Message) (*Enum, error) {
+	enum := &Enum{
+		Title:      item.Name,
+		AllowAlias: allowAliasRegex.MatchString(item.Body),
+		Flags:      flagsRegex.MatchString(item.Body),
+		Comment:    item.Comment,
+	}
+
+	if parent != nil {
+		// protoc-gen-go names nested enums after their message, and prefixes their values with it.
+		enum.Title = parent.Title + item.Name
+		enum.ProtoName = parent.ProtoName + "_" + item.Name
+		enum.ProtoValuePrefix = parent.ProtoName
+		enum.FullName = parent.FullName + "." + item.Name
+	}
+
+	// Extract for the value of the enum
+	values, err := extractValues(item.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while extracking values for title (%s): %w", enum.Title, err)
+	}
+
+	scopeValues(values, parent)
+
+	if err := setValues(enum, values); err != nil {
+		return nil, err
+	}
+
+	return enum, nil
+}
+
+// scopeValues prefixes the constants of the values of an enum nested in parent with the parent's name,
+// like protoc-gen-go does, as sibling messages may nest enums with the same value names.
+func scopeValues(values []*Value, parent *Message) {
+	if parent == nil {
+		return
+	}
+
+	for _, value := range values {
+		value.StringValue = parent.Title + value.StringValue
+	}
+}
+
+// setValues checks the values, in declaration order, and stores them sorted by number in the enum.
+func setValues(enum *Enum, values []*Value) error {
+	if len(values) == 0 {
+		return fmt.Errorf("enum %s has no values", enum.Title)
+	}
+
+	if err := markAliases(values, enum.AllowAlias); err != nil {
+		return fmt.Errorf("error occurred while checking values for title (%s): %w", enum.Title, err)
+	}
+
+	enum.Values = values
+	// Proto falls back to the first declared value, so remember it before sorting.
+	enum.DefaultValue = values[0]
+
+	// Stable, so aliases stay right after the value they alias.
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].NumberValue < values[j].NumberValue
+	})
+
+	return nil
+}
+
+// markAliases links every value that reuses a number to the first value declared with that number.
+// Reusing a number is only legal when the enum sets "option allow_alias = true;".
+func markAliases(values []*Value, allowAlias bool) error {
+	declared := make(map[int]*Value, len(values))
+
+	for _, value := range values {
+		original, ok := declared[value.NumberValue]
+		if !ok {
+			declared[value.NumberValue] = value
+			continue
+		}
+
+		if !allowAlias {
+			return fmt.Errorf("%s reuses the number %d of %s, set \"option allow_alias = true;\" to declare aliases",
+				value.OriginalStringValue, value.NumberValue, original.OriginalStringValue)
+		}
+
+		value.AliasOf = original
+	}
+
+	return nil
+}
+
+// extractValues tries to extract
+//
+//	// TIME_UNIT_UNSPECIFIED.
+//	TIME_UNIT_UNSPECIFIED = 0;
+//	// TIME_UNIT_SECOND.
+//	TIME_UNIT_SECOND = 1;
+//
+// to a bunch of Value format.
+func extractValues(input string) ([]*Value, error) {
+	commentPattern := regexp.MustCompile(`^// (.+)`)
+	commentTracker := ""
+	// Sometimes, the comment is formed by combining multiple lines. Sometimes, there's no comment.
+	// So just to be sure, we'll traverse line by line.
+	lines := strings.Split(input, "\n")
+
+	result := make([]*Value, 0)
+
+	for _, line := range lines {
+		// Trim the line first, it's usually starts with a bunch of whitespace.
+		line = strings.Trim(line, " ")
+
+		if len(line) == 0 {
+			continue
+		}
+
+		// if the line starts wih '//', it's a comment.
+		// we store the comment in the commentTracker then continue to another line
+		if commentMatch := commentPattern.MatchString(line); commentMatch {
+			if commentTracker == "" {
+				commentTracker = line
+			} else {
+				commentTracker = commentTracker + "\n" + line
+			}
+
+			continue
+		}
+
+		// Enum options (e.g. allow_alias) and reserved ranges are not values.
+		if strings.HasPrefix(line, "option ") || strings.HasPrefix(line, "reserved ") {
+			commentTracker = ""
+
+			continue
+		}
+
+		// otherwise, it's actual content, extract the string and the number value.
+		// 1. Extract OriginalStringValue, String and NumberValue
+		// 2. Store the commentTracker in a Value object,
+		// 3. and reset that commentTracker to start recording the other Value Objects
+
+		// 1. Extract String and NumberValue
+
+		// Remove all the white space and potential ";" at the end.
+		line = strings.Replace(line, " ", "", -1)
+		line = strings.Replace(line, ";", "", -1)
+
+		// Take the value options off first: (StringValue) = (NumberValue) [deprecated = true]
+		var deprecated bool
+		if optionsMatch := valueOptionsPattern.FindStringSubmatch(line); optionsMatch != nil {
+			deprecated = isDeprecated(optionsMatch[1])
+			line = strings.TrimSuffix(line, optionsMatch[0])
+		}
+
+		contents := strings.Split(line, "=")
+		if len(contents) != 2 {
+			return nil, fmt.Errorf("content value for enum protobuf is expected to have this format: (StringValue) = (NumberValue), received content: %s",
+				line)
+		}
+
+		// We go with this format:
+		// (StringValue) = (NumberValue), so we'll always expect StringValue to be on the left [0] and NumberValue to be on the right [1].
+		// Also, for the getter in go, we don't want all CAPS, we want PascalCase instead.
+		originalStringValue := contents[0]
+		stringValue := convertSnekToPascalCase(contents[0])
+		numberValue, err := parseIntLit(contents[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert int for %s, content value for enum protobuf is expected to have this format: (StringValue) = (NumberValue): %w",
+				line, err)
+		}
+
+		// 2. Store the commentTracker in a Value object,
+		value := &Value{
+			OriginalStringValue: originalStringValue,
+			Comment:             commentTracker,
+			StringValue:         stringValue,
+			NumberValue:         int(numberValue),
+			Deprecated:          deprecated,
+		}
+
+		// 3. Reset.
+		commentTracker = ""
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// valueOptionsPattern matches the options at the end of a value line, once the white space is removed.
+var valueOptionsPattern = regexp.MustCompile(`\[(.*)\]$`)
+
+// isDeprecated reports whether the options, e.g. "deprecated=true,(custom)=1", mark the value as deprecated.
+func isDeprecated(options string) bool {
+	for _, option := range strings.Split(options, ",") {
+		if key, value, ok := strings.Cut(option, "="); ok && key == "deprecated" && value == "true" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseIntLit parses the number of an enum value, which proto writes in decimal, hex or octal.
+func parseIntLit(literal string) (int64, error) {
+	if !intLitRegex.MatchString(literal) {
+		return 0, fmt.Errorf("%q is not a decimal, hex or octal integer", literal)
+	}
+
+	// The literal is valid, base 0 reads its prefix the way proto does.
+	return strconv.ParseInt(literal, 0, 32)
+}
+
+// convertSnekToPascalCase converts UPPER_CASE_SNAKE to UpperCaseSnake.
+func convertSnekToPascalCase(input string) string {
+	// Split the input string into words using underscores
+	words := strings.Split(input, "_")
+
+	// Capitalize the first letter of each word
+	for i := range words {
+		words[i] = caser.String(words[i])
+	}
+
+	// Join the words together to form the UpperCamelCase string
+	upperCamel := strings.Join(words, "")
+
+	return upperCamel
+}
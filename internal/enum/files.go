@@ -0,0 +1,323 @@
+package enum
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// wellKnownImportPrefix is the directory of the well-known types, which are built in rather than read from disk.
+const wellKnownImportPrefix = "google/protobuf/"
+
+var (
+	packageRegex = regexp.MustCompile(`^package\s+([\w.]+)$`)
+	importRegex  = regexp.MustCompile(`^import\s+(public\s+|weak\s+)?"([^"]+)"$`)
+)
+
+type ProtoFile struct {
+	// Path is the path of the file relative to the include path it was found in, e.g. delivery/v1/reminder.proto.
+	Path     string
+	Package  string
+	Imports  []*ProtoImport
+	Enums    []*Enum
+	Messages []*Message
+}
+
+// GetPath returns the ProtoFile's Path.
+func (f *ProtoFile) GetPath() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.Path
+}
+
+// GetEnums returns the ProtoFile's Enums.
+func (f *ProtoFile) GetEnums() []*Enum {
+	if f == nil {
+		return nil
+	}
+
+	return f.Enums
+}
+
+// GetMessages returns the ProtoFile's Messages.
+func (f *ProtoFile) GetMessages() []*Message {
+	if f == nil {
+		return nil
+	}
+
+	return f.Messages
+}
+
+// OutputName returns the name of the Go file generated for the ProtoFile with the file layout.
+// Directories are folded into the name so every file lands in the same Go package:
+// delivery/v1/reminder.proto will be delivery_v1_reminder.go
+func (f *ProtoFile) OutputName() string {
+	return f.outputBase() + ".go"
+}
+
+// outputBase is the name of the files generated from the proto file in the file layout, without extension.
+func (f *ProtoFile) outputBase() string {
+	name := strings.TrimSuffix(filepath.ToSlash(f.GetPath()), ".proto")
+
+	return strings.ReplaceAll(name, "/", "_")
+}
+
+type ProtoImport struct {
+	Path   string
+	Public bool
+	// File is the imported file, nil for the well-known types.
+	File *ProtoFile
+}
+
+// protoLoader reads proto files and the files they import, each of them only once.
+type protoLoader struct {
+	includes []string
+	// files is keyed by the path relative to the include path.
+	files map[string]*ProtoFile
+}
+
+// LoadProtoFiles reads the proto files and directories given as inputs, along with everything they import.
+// Imports are looked up in the include paths, like protoc's -I. Only the files given as inputs are returned,
+// sorted by path, and all of their message fields are resolved.
+func LoadProtoFiles(inputs []string, includes []string) ([]*ProtoFile, error) {
+	if len(includes) == 0 {
+		includes = []string{"."}
+	}
+
+	loader := &protoLoader{
+		includes: includes,
+		files:    make(map[string]*ProtoFile),
+	}
+
+	paths, err := expandInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ProtoFile, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+
+	for _, path := range paths {
+		file, err := loader.loadInput(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[file.Path] {
+			continue
+		}
+
+		seen[file.Path] = true
+		files = append(files, file)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	for _, file := range files {
+		if err := resolveFieldTypes(file.Package, file.Messages, file.visibleTypes()); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Path, err)
+		}
+	}
+
+	return files, nil
+}
+
+// expandInputs replaces the directories among the inputs by the proto files they contain.
+func expandInputs(inputs []string) ([]string, error) {
+	paths := make([]string, 0, len(inputs))
+
+	for _, input := range inputs {
+		info, err := os.Stat(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			paths = append(paths, input)
+			continue
+		}
+
+		err = filepath.WalkDir(input, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !d.IsDir() && filepath.Ext(path) == ".proto" {
+				paths = append(paths, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
+// loadInput loads a file given on the command line, it's named relative to the include path containing it,
+// so it's the same ProtoFile when another file imports it.
+func (l *protoLoader) loadInput(path string) (*ProtoFile, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, include := range l.includes {
+		includeAbs, err := filepath.Abs(include)
+		if err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(includeAbs, abs)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		return l.load(filepath.ToSlash(rel), path, nil)
+	}
+
+	// Not under any include path, it can't be imported by name, so it's named after its base name.
+	return l.load(filepath.Base(path), path, nil)
+}
+
+// load parses the file at diskPath, known as path, then loads its imports.
+// importing is the chain of files being loaded, used to report import cycles.
+func (l *protoLoader) load(path string, diskPath string, importing []string) (*ProtoFile, error) {
+	if file, ok := l.files[path]; ok {
+		return file, nil
+	}
+
+	for _, p := range importing {
+		if p == path {
+			return nil, fmt.Errorf("import cycle: %s -> %s", strings.Join(importing, " -> "), path)
+		}
+	}
+
+	content, err := os.ReadFile(diskPath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parseProtoFile(path, string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, imp := range file.Imports {
+		// The well-known types are built in, see wellKnownTypes.
+		if strings.HasPrefix(imp.Path, wellKnownImportPrefix) {
+			continue
+		}
+
+		importDiskPath, ok := l.find(imp.Path)
+		if !ok {
+			return nil, fmt.Errorf("%s: import %q not found in include paths %s",
+				path, imp.Path, strings.Join(l.includes, ", "))
+		}
+
+		imp.File, err = l.load(imp.Path, importDiskPath, append(importing, path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.files[path] = file
+
+	return file, nil
+}
+
+// find returns where the imported path is on disk.
+func (l *protoLoader) find(path string) (string, bool) {
+	for _, include := range l.includes {
+		candidate := filepath.Join(include, filepath.FromSlash(path))
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// parseProtoFile extracts the package, imports, enums and messages of a proto file.
+func parseProtoFile(path string, content string) (*ProtoFile, error) {
+	items, err := scanProtoItems(content)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &ProtoFile{
+		Path: path,
+	}
+
+	for _, item := range items {
+		if item.Kind != "" {
+			continue
+		}
+
+		statement := strings.Join(strings.Fields(item.Statement), " ")
+
+		if match := packageRegex.FindStringSubmatch(statement); match != nil {
+			file.Package = match[1]
+		}
+
+		if match := importRegex.FindStringSubmatch(statement); match != nil {
+			file.Imports = append(file.Imports, &ProtoImport{
+				Path:   match[2],
+				Public: strings.TrimSpace(match[1]) == "public",
+			})
+		}
+	}
+
+	if file.Enums, err = extractEnum(content); err != nil {
+		return nil, err
+	}
+
+	messages, nested, err := extractMessages(content)
+	if err != nil {
+		return nil, err
+	}
+
+	file.Messages = messages
+	file.Enums = append(file.Enums, nested...)
+
+	return file, nil
+}
+
+// visibleTypes returns the types the file can refer to: its own, the ones of the files it imports,
+// and the ones those files import publicly.
+func (f *ProtoFile) visibleTypes() *visibleTypes {
+	visible := newVisibleTypes()
+	visible.add(f.Package, f.Messages, f.Enums)
+
+	for _, imp := range f.Imports {
+		imp.File.addExported(visible, make(map[string]bool))
+	}
+
+	return visible
+}
+
+// addExported registers the types of the file and of its public imports.
+func (f *ProtoFile) addExported(visible *visibleTypes, done map[string]bool) {
+	if f == nil || done[f.Path] {
+		return
+	}
+
+	done[f.Path] = true
+	visible.add(f.Package, f.Messages, f.Enums)
+
+	for _, imp := range f.Imports {
+		if imp.Public {
+			imp.File.addExported(visible, done)
+		}
+	}
+}
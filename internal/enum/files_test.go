@@ -0,0 +1,35 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestLoadProtoFiles(t *testing.T) {
+	t.Parallel()
+
+	files, err := enum.LoadProtoFiles([]string{"testdata/text"}, []string{"testdata/text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := enum.GenerateFiles(files, &enum.Options{
+		NameStyle: enum.NameStyleProto,
+		Layout:    enum.LayoutFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshotOutputs(t, outputs)
+}
+
+func TestLoadProtoFiles_UnresolvedImport(t *testing.T) {
+	t.Parallel()
+
+	_, err := enum.LoadProtoFiles([]string{"testdata/text/delivery.proto"}, []string{"testdata"})
+	if err == nil {
+		t.Fatal("expected an error for the unresolved import")
+	}
+}
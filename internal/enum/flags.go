@@ -0,0 +1,257 @@
+package enum
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+// FlagsOption is the enum option marking an enum as a set of bit flags in proto text:
+//
+//	enum Permission {
+//	  option (accessory.flags) = true;
+//	  ...
+//	}
+const FlagsOption = "(accessory.flags)"
+
+// ParseFlagsEnums parses the comma separated names of the enums to generate as bit flags.
+func ParseFlagsEnums(value string) []string {
+	names := make([]string, 0)
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// ValidateFlags checks that the values of a flags enum can be combined: zero is the empty set,
+// every other value is either a single bit or a combination of the single bit values.
+func (e *Enum) ValidateFlags() error {
+	if e == nil || !e.Flags {
+		return nil
+	}
+
+	var bits int
+	for _, value := range e.flagValues() {
+		bits = bits | value.NumberValue
+	}
+
+	for _, value := range e.GetValues() {
+		if value.NumberValue < 0 {
+			return fmt.Errorf("flags enum %s: %s has the negative number %d", e.Title, value.OriginalStringValue, value.NumberValue)
+		}
+
+		if value.NumberValue&^bits != 0 {
+			return fmt.Errorf("flags enum %s: %s (%d) is neither a power of two nor a combination of the other values",
+				e.Title, value.OriginalStringValue, value.NumberValue)
+		}
+	}
+
+	return nil
+}
+
+// flagValues returns the values holding a single bit, aliases excluded.
+func (e *Enum) flagValues() []*Value {
+	values := make([]*Value, 0, len(e.GetValues()))
+
+	for _, value := range e.GetValues() {
+		n := value.NumberValue
+		if value.IsAlias() || n <= 0 || n&(n-1) != 0 {
+			continue
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// flagList lays out the single bit values one per line, format gets the Go and the proto name of each.
+func (e *Enum) flagList(format string) string {
+	result := ""
+
+	for _, value := range e.flagValues() {
+		result = result + "\n\t\t" + fmt.Sprintf(format, value.StringValue, value.OriginalStringValue) + ","
+	}
+
+	return result
+}
+
+// FlagsMethods generates the set operations and String of a flags enum. The parameters and variables
+// are words, so they never collide with the single letter receiver.
+func (e *Enum) FlagsMethods() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+// %sFlags returns the single flags of the %s, in ascending order.
+func %sFlags() []%s {
+	return []%s{%s
+	}
+}
+
+// Has reports whether all the flags of flag are set in the %s.
+func (%s %s) Has(flag %s) bool {
+	return %s&flag == flag
+}
+
+// Set returns the %s with the flags of flag set.
+func (%s %s) Set(flag %s) %s {
+	return %s | flag
+}
+
+// Clear returns the %s with the flags of flag cleared.
+func (%s %s) Clear(flag %s) %s {
+	return %s &^ flag
+}
+
+// Toggle returns the %s with the flags of flag flipped.
+func (%s %s) Toggle(flag %s) %s {
+	return %s ^ flag
+}
+
+// String renders the set flags joined with "|", e.g. %s.
+func (%s %s) String() string {
+	if %s == 0 {
+		return %q
+	}
+
+	names := make([]string, 0)
+	rest := %s
+
+	for _, entry := range []struct {
+		flag %s
+		name string
+	}{%s
+	} {
+		if %s.Has(entry.flag) {
+			names = append(names, entry.name)
+			rest = rest.Clear(entry.flag)
+		}
+	}
+
+	// Bits the model doesn't know about are still shown.
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%%x", int32(rest)))
+	}
+
+	return strings.Join(names, "|")
+}`,
+		e.Title, e.Title, e.Title, e.Title, e.Title, e.flagList("%[1]s"),
+		e.Title, e.GetReceiver(), e.Title, e.Title, e.GetReceiver(),
+		e.Title, e.GetReceiver(), e.Title, e.Title, e.Title, e.GetReceiver(),
+		e.Title, e.GetReceiver(), e.Title, e.Title, e.Title, e.GetReceiver(),
+		e.Title, e.GetReceiver(), e.Title, e.Title, e.Title, e.GetReceiver(),
+		e.flagsExample(), e.GetReceiver(), e.Title, e.GetReceiver(), e.zeroFlagName(), e.GetReceiver(),
+		e.Title, e.flagList("{%s, %q}"), e.GetReceiver())
+}
+
+// FlagsToProto generates ToProto, converting the flags to the values of a repeated proto enum field.
+func (e *Enum) FlagsToProto() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+// ToProto converts the %s to the Protobuf values of its flags, for a repeated field.
+func (%s %s) ToProto() []%s.%s {
+	values := make([]%s.%s, 0)
+
+	for _, flag := range %sFlags() {
+		if %s.Has(flag) {
+			values = append(values, %s.%s(flag))
+		}
+	}
+
+	return values
+}`, e.Title, e.GetReceiver(), e.Title, e.GetProtoPackage(), e.GetProtoName(),
+		e.GetProtoPackage(), e.GetProtoName(), e.Title, e.GetReceiver(), e.GetProtoPackage(), e.GetProtoName())
+}
+
+// FlagsProtoToEnum generates ProtoTo<Enum>, combining the values of a repeated proto enum field.
+func (e *Enum) FlagsProtoToEnum() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+// ProtoTo%s combines the Protobuf values of a repeated field to the %s.
+func ProtoTo%s(values []%s.%s) %s {
+	var %s %s
+
+	for _, value := range values {
+		%s = %s.Set(%s(value))
+	}
+
+	return %s
+}`, e.Title, e.Title, e.Title, e.GetProtoPackage(), e.GetProtoName(), e.Title,
+		e.GetReceiver(), e.Title, e.GetReceiver(), e.GetReceiver(), e.Title, e.GetReceiver())
+}
+
+// GenerateFlagsTest generates the round trip test of a flags enum: no flag, every single flag and all of them.
+func (e *Enum) GenerateFlagsTest(style string) (string, error) {
+	if e == nil {
+		return "", nil
+	}
+
+	protoType := fmt.Sprintf("[]%s.%s", e.GetProtoPackage(), e.GetProtoName())
+
+	all := make([]string, 0)
+	allProto := make([]string, 0)
+	cases := []*testgen.Case{
+		{
+			Name: "given no flag",
+			Data: fmt.Sprintf("args: 0,\nwantProto: %s{},", protoType),
+		},
+	}
+
+	for _, value := range e.flagValues() {
+		modelValue := fmt.Sprintf("%s.%s", e.GetModelPackage(), value.StringValue)
+		protoValue := fmt.Sprintf("%s.%s_%s", e.GetProtoPackage(), e.GetProtoValuePrefix(), value.OriginalStringValue)
+
+		all = append(all, modelValue)
+		allProto = append(allProto, protoValue)
+
+		cases = append(cases, &testgen.Case{
+			Name: fmt.Sprintf("given %s flag", value.StringValue),
+			Data: fmt.Sprintf("args: %s,\nwantProto: %s{%s},", modelValue, protoType, protoValue),
+		})
+	}
+
+	cases = append(cases, &testgen.Case{
+		Name: "given all flags",
+		Data: fmt.Sprintf("args: %s,\nwantProto: %s{%s},", strings.Join(all, " | "), protoType, strings.Join(allProto, ", ")),
+	})
+
+	return e.roundTripTest(style, protoType, cases)
+}
+
+// zeroFlagName is what String returns when no flag is set.
+func (e *Enum) zeroFlagName() string {
+	for _, value := range e.GetValues() {
+		if value.NumberValue == 0 {
+			return value.OriginalStringValue
+		}
+	}
+
+	return "0"
+}
+
+// flagsExample is the String of the first two flags, for the doc comment.
+func (e *Enum) flagsExample() string {
+	names := make([]string, 0, 2)
+	for _, value := range e.flagValues() {
+		if len(names) == 2 {
+			break
+		}
+
+		names = append(names, value.OriginalStringValue)
+	}
+
+	return strings.Join(names, "|")
+}
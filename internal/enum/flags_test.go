@@ -0,0 +1,65 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateFiles_Flags(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		file    string
+		flags   []string
+		wantErr bool
+	}{
+		"Option": {
+			file: "testdata/flags/permission.proto",
+		},
+		"OptionAndFlag": {
+			file:  "testdata/flags/permission.proto",
+			flags: []string{"Channel"},
+		},
+		"NotCombinable": {
+			file:    "testdata/flags/permission.proto",
+			flags:   []string{"Priority"},
+			wantErr: true,
+		},
+		// The receiver f of Feature must not be shadowed by the parameters of the set operations.
+		"ReceiverF": {
+			file: "testdata/flags/feature.proto",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := enum.LoadProtoFiles([]string{tt.file}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				NameStyle: enum.NameStyleProto,
+				Layout:    enum.LayoutFile,
+				Flags:     tt.flags,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error for the enum that can't be used as flags")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
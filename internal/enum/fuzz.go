@@ -0,0 +1,59 @@
+package enum
+
+import "fmt"
+
+// GenerateFuzzTest generates a fuzz test checking that converting any proto number to the model
+// and back to proto is stable: converting the result again gives the same proto value, without panicking.
+func (e *Enum) GenerateFuzzTest() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+func Fuzz%s_Convert(f *testing.F) {%s
+	f.Fuzz(func(t *testing.T, number int32) {
+		proto := %s.ProtoTo%s(%s.%s(number)).ToProto()
+		again := %s.ProtoTo%s(proto).ToProto()
+		if again != proto {
+			t.Errorf("ProtoTo%s(%%d).ToProto() = %%v is not stable, converting it again gives %%v", number, proto, again)
+		}
+	})
+}
+`, e.Title, e.fuzzSeeds(), e.GetModelPackage(), e.Title, e.GetProtoPackage(), e.GetProtoName(),
+		e.GetModelPackage(), e.Title, e.Title)
+}
+
+// GenerateFlagsFuzzTest generates the fuzz test of a flags enum, converting a single proto value at a time.
+func (e *Enum) GenerateFlagsFuzzTest() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+func Fuzz%s_Convert(f *testing.F) {%s
+	f.Fuzz(func(t *testing.T, number int32) {
+		proto := %s.ProtoTo%s([]%s.%s{%s.%s(number)}).ToProto()
+		again := %s.ProtoTo%s(proto).ToProto()
+		if !reflect.DeepEqual(again, proto) {
+			t.Errorf("ProtoTo%s(%%d).ToProto() = %%v is not stable, converting it again gives %%v", number, proto, again)
+		}
+	})
+}
+`, e.Title, e.fuzzSeeds(), e.GetModelPackage(), e.Title, e.GetProtoPackage(), e.GetProtoName(), e.GetProtoPackage(), e.GetProtoName(),
+		e.GetModelPackage(), e.Title, e.Title)
+}
+
+// fuzzSeeds adds the number of every declared value to the corpus.
+func (e *Enum) fuzzSeeds() string {
+	result := ""
+
+	for _, value := range e.GetValues() {
+		if value.IsAlias() {
+			continue
+		}
+
+		result = result + fmt.Sprintf("\n\tf.Add(int32(%d))", value.GetNumberValue())
+	}
+
+	return result
+}
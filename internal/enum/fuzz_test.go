@@ -0,0 +1,57 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/enum"
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+func TestGenerateFiles_Fuzz(t *testing.T) {
+	t.Parallel()
+
+	files, err := enum.LoadProtoFiles([]string{"testdata/flags/permission.proto"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := enum.GenerateFiles(files, &enum.Options{
+		NameStyle: enum.NameStyleProto,
+		Layout:    enum.LayoutFile,
+		Fuzz:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshotOutputs(t, outputs)
+}
+
+// Fuzz tests only need the testing package, so the test file is written even without the other tests.
+func TestGenerateReverseFiles_FuzzWithoutTests(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := accessor.ParsePackage("testdata/reverse/order")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := enum.GenerateReverseFiles(pkg, &enum.ReverseOptions{
+		GoPackage: "example.com/gen/order/v1;orderv1",
+		TestStyle: testgen.StyleNone,
+		Fuzz:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := make([]*enum.OutputFile, 0, 1)
+	for _, output := range outputs {
+		if output.Name == "order_proto_test.go" {
+			tests = append(tests, output)
+		}
+	}
+
+	snapshotOutputs(t, tests)
+}
@@ -0,0 +1,386 @@
+package enum
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+// Output layouts that can be selected with -layout.
+const (
+	// LayoutEnum writes one Go file per enum and per message.
+	LayoutEnum = "enum"
+	// LayoutFile writes one Go file per proto file.
+	LayoutFile = "file"
+)
+
+// Options holds what was asked on the command line, or in the plugin parameter, for every file.
+type Options struct {
+	Families  MethodFamilies
+	NameStyle string
+	Strict    bool
+	Layout    string
+	// ProtoPackage overrides the Go package of the proto enums when set.
+	ProtoPackage string
+	// ModelPackage overrides the Go package of the model enums when set.
+	ModelPackage string
+	// Package is the package clause of the generated files, DefaultOutputPackage if empty.
+	Package string
+	// Flags names the enums generated as bit flags, on top of the ones marked with the FlagsOption.
+	Flags []string
+	// Emit is the set of backends to generate, only Go if empty.
+	Emit Emitters
+	// PreviousSQL is the DDL generated by an earlier run, the sql backend writes
+	// the migration from it to the current enums when set.
+	PreviousSQL string
+	// TestStyle is the style of the generated tests, testgen.StyleGt if empty.
+	TestStyle string
+	// Fuzz adds a fuzz test of the conversions of every enum.
+	Fuzz bool
+}
+
+// Validate checks the options that can't be checked while parsing them.
+func (o *Options) Validate() error {
+	if err := ValidateNameStyle(o.NameStyle); err != nil {
+		return err
+	}
+
+	if o.Layout != LayoutEnum && o.Layout != LayoutFile {
+		return fmt.Errorf("unknown layout %q, expected %s or %s", o.Layout, LayoutEnum, LayoutFile)
+	}
+
+	if o.TestStyle != "" {
+		if err := testgen.Validate(o.TestStyle); err != nil {
+			return err
+		}
+	}
+
+	if o.PreviousSQL != "" && !o.emits(EmitSQL) {
+		return fmt.Errorf("the previous DDL is only used by the %s emitter", EmitSQL)
+	}
+
+	return nil
+}
+
+func (o *Options) outputPackage() string {
+	if o.Package == "" {
+		return DefaultOutputPackage
+	}
+
+	return o.Package
+}
+
+func (o *Options) testStyle() string {
+	if o.TestStyle == "" {
+		return testgen.StyleGt
+	}
+
+	return o.TestStyle
+}
+
+func (o *Options) emits(emitter string) bool {
+	if len(o.Emit) == 0 {
+		return emitter == EmitGo
+	}
+
+	return o.Emit[emitter]
+}
+
+// jsonNames reports whether the Go enum is written to JSON with its names, through MarshalJSON
+// or MarshalText, rather than as a number. Flags enums don't get the method families.
+func (o *Options) jsonNames(enum *Enum) bool {
+	if o.isFlags(enum) {
+		return false
+	}
+
+	return o.Families[withJSON] || o.Families[withText]
+}
+
+func (o *Options) isFlags(enum *Enum) bool {
+	if enum.GetFlags() {
+		return true
+	}
+
+	for _, name := range o.Flags {
+		if name == enum.GetTitle() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OutputFile is a generated file, Name is relative to the output directory.
+type OutputFile struct {
+	Name    string
+	Content []byte
+}
+
+// GenerateFiles generates the files of every backend of the options for the proto files,
+// with the layout of the options.
+func GenerateFiles(files []*ProtoFile, opts *Options) ([]*OutputFile, error) {
+	outputs := make([]*OutputFile, 0)
+
+	if opts.emits(EmitGo) {
+		goOutputs, err := generateGoFiles(files, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, goOutputs...)
+	}
+
+	if opts.emits(EmitTypeScript) {
+		outputs = append(outputs, generateTypeScriptFiles(files, opts)...)
+	}
+
+	if opts.emits(EmitJSONSchema) {
+		schemaOutputs, err := generateJSONSchemaFiles(files, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, schemaOutputs...)
+	}
+
+	if opts.emits(EmitSQL) {
+		sqlOutputs, err := generateSQLFiles(files, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, sqlOutputs...)
+	}
+
+	return outputs, nil
+}
+
+// generateGoFiles generates the Go files of the proto files with the layout of the options.
+func generateGoFiles(files []*ProtoFile, opts *Options) ([]*OutputFile, error) {
+	outputs := make([]*OutputFile, 0)
+
+	if opts.Layout == LayoutFile {
+		for _, file := range files {
+			content, err := generateProtoFile(file, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			outputs = append(outputs, &OutputFile{Name: file.OutputName(), Content: content})
+		}
+
+		return outputs, nil
+	}
+
+	// Every enum and message gets its own file, so their names must be unique across the files.
+	declared := make(map[string]string)
+	claim := func(name string, file *ProtoFile) error {
+		if previous, ok := declared[name]; ok {
+			return fmt.Errorf("%s is declared in both %s and %s, use the %s layout", name, previous, file.GetPath(), LayoutFile)
+		}
+
+		declared[name] = file.GetPath()
+
+		return nil
+	}
+
+	for _, file := range files {
+		// Export the extracted data with the formatted template.
+		for _, enum := range file.GetEnums() {
+			if err := claim(enum.GetTitle(), file); err != nil {
+				return nil, err
+			}
+
+			imports := enumImports(enum, opts)
+
+			generated, err := generateEnum(enum, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			result := fmt.Sprintf("// Code generated by accessory; DO NOT EDIT.\n// source: %s\n\npackage %s\n%s%s",
+				file.GetPath(), opts.outputPackage(), importBlock(imports), generated)
+
+			src, err := format.Source([]byte(result))
+			if err != nil {
+				return nil, fmt.Errorf("failed to format the output of %s: %w", enum.GetTitle(), err)
+			}
+
+			outputs = append(outputs, &OutputFile{Name: enum.GetTitle() + ".go", Content: src})
+		}
+
+		// Export the model structs, they are complete Go files so accessory can run on them right away.
+		for _, message := range file.GetMessages() {
+			if err := claim(message.GetTitle(), file); err != nil {
+				return nil, err
+			}
+
+			content, err := GenerateMessageFile(opts.outputPackage(), message)
+			if err != nil {
+				return nil, err
+			}
+
+			outputs = append(outputs, &OutputFile{Name: message.GetTitle() + ".go", Content: content})
+		}
+	}
+
+	return outputs, nil
+}
+
+// generateEnum generates everything for the enum, without the package clause.
+func generateEnum(enum *Enum, opts *Options) (string, error) {
+	if opts.ProtoPackage != "" {
+		enum.ProtoPackage = opts.ProtoPackage
+	}
+
+	if opts.ModelPackage != "" {
+		enum.ModelPackage = opts.ModelPackage
+	}
+
+	if opts.isFlags(enum) {
+		enum.Flags = true
+
+		return generateFlagsEnum(enum, opts)
+	}
+
+	test, err := enum.GenerateTest(opts.testStyle())
+	if err != nil {
+		return "", err
+	}
+
+	if opts.Fuzz {
+		test = test + enum.GenerateFuzzTest()
+	}
+
+	result := fmt.Sprintf(`
+		%s
+		%s
+		%s
+		%s
+		%s`, enum.ToString(), enum.ToProto(), enum.ProtoToEnum(), enum.GenerateMethods(opts.Families, opts.NameStyle), test)
+
+	if opts.Strict {
+		result = fmt.Sprintf(`%s
+		%s
+		%s
+		%s`, result, enum.IsValid(), enum.ProtoToEnumStrict(), enum.GenerateExhaustiveTest(opts.testStyle()))
+	}
+
+	return result, nil
+}
+
+// generateFlagsEnum generates a flags enum, the method families and the strict conversion
+// are left out as String and the conversions already handle any combination of flags.
+func generateFlagsEnum(enum *Enum, opts *Options) (string, error) {
+	if err := enum.ValidateFlags(); err != nil {
+		return "", err
+	}
+
+	test, err := enum.GenerateFlagsTest(opts.testStyle())
+	if err != nil {
+		return "", err
+	}
+
+	if opts.Fuzz {
+		test = test + enum.GenerateFlagsFuzzTest()
+	}
+
+	return fmt.Sprintf(`
+		%s
+		%s
+		%s
+		%s
+		%s`, enum.ToString(), enum.FlagsMethods(), enum.FlagsToProto(), enum.FlagsProtoToEnum(), test), nil
+}
+
+// generateProtoFile generates a single formatted Go file with the enums and the model structs of the file.
+func generateProtoFile(file *ProtoFile, opts *Options) ([]byte, error) {
+	result := fmt.Sprintf("// Code generated by accessory; DO NOT EDIT.\n// source: %s\n\npackage %s\n", file.GetPath(), opts.outputPackage())
+
+	imports := make([]string, 0)
+	for _, enum := range file.GetEnums() {
+		imports = append(imports, enumImports(enum, opts)...)
+	}
+
+	for _, message := range file.GetMessages() {
+		imports = append(imports, message.Imports()...)
+	}
+
+	result = result + importBlock(imports)
+
+	for _, enum := range file.GetEnums() {
+		generated, err := generateEnum(enum, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.GetPath(), err)
+		}
+
+		result = result + generated
+	}
+
+	for _, message := range file.GetMessages() {
+		result = result + message.ToStruct()
+	}
+
+	src, err := format.Source([]byte(result))
+	if err != nil {
+		return []byte(result), fmt.Errorf("failed to format the output of %s: %w", file.GetPath(), err)
+	}
+
+	return src, nil
+}
+
+// enumImports returns the packages the code generated for the enum uses.
+func enumImports(enum *Enum, opts *Options) []string {
+	// The tests are generated along with the enum.
+	imports := testgen.Imports(opts.testStyle())
+	if opts.Fuzz {
+		imports = append(imports, "testing")
+	}
+
+	// String of flags enums joins the names of the flags and shows the unknown bits,
+	// their fuzz test compares the repeated proto fields with reflect.DeepEqual.
+	if opts.isFlags(enum) {
+		if opts.Fuzz {
+			imports = append(imports, "reflect")
+		}
+
+		return append(imports, "fmt", "strings")
+	}
+
+	imports = append(imports, enum.methodImports(opts.Families)...)
+
+	// ProtoTo<Enum>Strict reports the unknown values with fmt.Errorf.
+	if opts.Strict {
+		imports = append(imports, "fmt")
+	}
+
+	return imports
+}
+
+// importBlock declares the imports once each and sorted, nothing when there is none.
+func importBlock(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	unique := make(map[string]bool, len(imports))
+	sorted := make([]string, 0, len(imports))
+
+	for _, imp := range imports {
+		if !unique[imp] {
+			unique[imp] = true
+			sorted = append(sorted, imp)
+		}
+	}
+
+	sort.Strings(sorted)
+
+	block := "\nimport (\n"
+	for _, imp := range sorted {
+		block = block + fmt.Sprintf("\t%q\n", imp)
+	}
+
+	return block + ")\n"
+}
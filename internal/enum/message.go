@@ -0,0 +1,720 @@
+package enum
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	labelRepeated = "repeated"
+	labelOptional = "optional"
+)
+
+// accessorTag is the tag put on every generated model field, so accessory can run on the output as it is.
+const accessorTag = `accessor:"getter,setter"`
+
+// scalarTypes maps proto scalar types to their Go types.
+var scalarTypes = map[string]string{
+	"double":   "float64",
+	"float":    "float32",
+	"int32":    "int32",
+	"int64":    "int64",
+	"uint32":   "uint32",
+	"uint64":   "uint64",
+	"sint32":   "int32",
+	"sint64":   "int64",
+	"fixed32":  "uint32",
+	"fixed64":  "uint64",
+	"sfixed32": "int32",
+	"sfixed64": "int64",
+	"bool":     "bool",
+	"string":   "string",
+	"bytes":    "[]byte",
+}
+
+// wellKnownTypes maps the supported google.protobuf types to their Go model types.
+// The wrappers are nullable scalars, so they become pointers.
+var wellKnownTypes = map[string]string{
+	"google.protobuf.Timestamp":   "time.Time",
+	"google.protobuf.Duration":    "time.Duration",
+	"google.protobuf.DoubleValue": "*float64",
+	"google.protobuf.FloatValue":  "*float32",
+	"google.protobuf.Int64Value":  "*int64",
+	"google.protobuf.UInt64Value": "*uint64",
+	"google.protobuf.Int32Value":  "*int32",
+	"google.protobuf.UInt32Value": "*uint32",
+	"google.protobuf.BoolValue":   "*bool",
+	"google.protobuf.StringValue": "*string",
+	"google.protobuf.BytesValue":  "[]byte",
+}
+
+type Message struct {
+	// Title is the name of the Go model, nested messages are prefixed with their parents: OuterInner.
+	Title string
+	// ProtoName is the name protoc-gen-go gives to the message: Outer_Inner.
+	ProtoName string
+	// FullName is the name of the message relative to the proto package: Outer.Inner.
+	FullName string
+	Comment  string
+	Fields   []*Field
+}
+
+// GetTitle returns the Message's Title.
+func (m *Message) GetTitle() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.Title
+}
+
+// GetProtoName returns the Message's ProtoName.
+func (m *Message) GetProtoName() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.ProtoName
+}
+
+// GetFullName returns the Message's FullName.
+func (m *Message) GetFullName() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.FullName
+}
+
+// GetComment returns the Message's Comment.
+func (m *Message) GetComment() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.Comment
+}
+
+// GetFields returns the Message's Fields.
+func (m *Message) GetFields() []*Field {
+	if m == nil {
+		return nil
+	}
+
+	return m.Fields
+}
+
+// ToStruct generates the model struct of the Message.
+func (m *Message) ToStruct() string {
+	if m == nil {
+		return ""
+	}
+
+	comment := m.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("// %s is the model of the %s message.", m.Title, m.FullName)
+	}
+
+	fields := ""
+	for _, field := range m.Fields {
+		fields = fields + field.ToStructField()
+	}
+
+	return fmt.Sprintf(`
+%s
+type %s struct {%s
+}
+`, comment, m.Title, fields)
+}
+
+// Imports returns the packages the model struct of the Message depends on.
+func (m *Message) Imports() []string {
+	for _, field := range m.GetFields() {
+		if strings.Contains(field.GetGoType(), "time.") {
+			return []string{"time"}
+		}
+	}
+
+	return nil
+}
+
+type Field struct {
+	// OriginalName is the field name in proto: delivery_at.
+	OriginalName string
+	// Name is the unexported Go field name: deliveryAt.
+	Name string
+	// Label is either empty, "repeated" or "optional".
+	Label string
+	// ProtoType is the type as written in proto, the value type for maps.
+	ProtoType string
+	// MapKey is the key type of a map field, empty for other fields.
+	MapKey string
+	Number int
+	// Oneof is the name of the oneof the field belongs to, empty if it doesn't.
+	Oneof   string
+	Comment string
+	// GoType is the resolved type of the model field.
+	GoType string
+}
+
+// GetName returns the Field's Name.
+func (f *Field) GetName() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.Name
+}
+
+// GetOriginalName returns the Field's OriginalName.
+func (f *Field) GetOriginalName() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.OriginalName
+}
+
+// GetGoType returns the Field's GoType.
+func (f *Field) GetGoType() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.GoType
+}
+
+// GetOneof returns the Field's Oneof.
+func (f *Field) GetOneof() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.Oneof
+}
+
+// ToStructField generates the line of the model struct for the Field.
+func (f *Field) ToStructField() string {
+	if f == nil {
+		return ""
+	}
+
+	tag := accessorTag
+	if f.isWrapper() {
+		// accessory converts the field back to the wrapper, []byte included, only with the wrapper option.
+		tag = strings.TrimSuffix(tag, `"`) + ",wrapper" + `"`
+	}
+
+	comment := f.Comment
+	if f.Oneof != "" {
+		// accessory converts the oneof fields with a type switch.
+		tag = strings.TrimSuffix(tag, `"`) + ",oneof=" + f.Oneof + `"`
+
+		oneofComment := fmt.Sprintf("// Part of the %s oneof, at most one of its fields is set.", f.Oneof)
+		if comment == "" {
+			comment = oneofComment
+		} else {
+			comment = comment + "\n" + oneofComment
+		}
+	}
+
+	if comment == "" {
+		return fmt.Sprintf("\n\t%s %s `%s`", f.Name, f.GoType, tag)
+	}
+
+	return fmt.Sprintf("\n\t%s\n\t%s %s `%s`", strings.ReplaceAll(comment, "\n", "\n\t"), f.Name, f.GoType, tag)
+}
+
+// isWrapper reports whether the Field holds a single google.protobuf wrapper, e.g. google.protobuf.StringValue.
+func (f *Field) isWrapper() bool {
+	protoType := strings.TrimPrefix(f.ProtoType, ".")
+	if f.Label == labelRepeated || f.MapKey != "" || !strings.HasPrefix(protoType, "google.protobuf.") {
+		return false
+	}
+
+	_, ok := wellKnownTypes[protoType]
+
+	return ok && strings.HasSuffix(protoType, "Value")
+}
+
+// GenerateMessageFile generates a formatted Go file holding the model struct of the Message.
+func GenerateMessageFile(pkgName string, m *Message) ([]byte, error) {
+	result := fmt.Sprintf("// Code generated by accessory; DO NOT EDIT.\n\npackage %s\n", pkgName)
+
+	if imports := m.Imports(); len(imports) > 0 {
+		result = result + "\nimport (\n"
+		for _, imp := range imports {
+			result = result + fmt.Sprintf("\t%q\n", imp)
+		}
+		result = result + ")\n"
+	}
+
+	result = result + m.ToStruct()
+
+	src, err := format.Source([]byte(result))
+	if err != nil {
+		return []byte(result), fmt.Errorf("failed to format the model of %s: %w", m.GetTitle(), err)
+	}
+
+	return src, nil
+}
+
+// protoItem is a top level element of a proto file or of a message body.
+type protoItem struct {
+	// Kind is the keyword opening a block (message, enum, oneof, service...) or empty for a statement.
+	Kind    string
+	Name    string
+	Body    string
+	Comment string
+	// Statement is the content of a statement, without the trailing ";".
+	Statement string
+}
+
+var blockRegex = regexp.MustCompile(`^(\w+)\s+([\w.]*)\s*{`)
+
+// scanProtoItems splits the input into blocks ("message X { ... }") and statements ("string name = 1;"),
+// keeping the comment lines right above each of them.
+func scanProtoItems(input string) ([]*protoItem, error) {
+	items := make([]*protoItem, 0)
+	commentTracker := ""
+
+	for i := 0; i < len(input); {
+		rest := input[i:]
+		trimmed := strings.TrimLeft(rest, " \t\r\n")
+		i = i + len(rest) - len(trimmed)
+
+		if trimmed == "" {
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "//"):
+			end := strings.Index(trimmed, "\n")
+			if end == -1 {
+				end = len(trimmed)
+			}
+
+			line := strings.TrimRight(trimmed[:end], " \t\r")
+			if commentTracker == "" {
+				commentTracker = line
+			} else {
+				commentTracker = commentTracker + "\n" + line
+			}
+
+			i = i + end
+		case strings.HasPrefix(trimmed, "/*"):
+			end := strings.Index(trimmed, "*/")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated block comment")
+			}
+
+			i = i + end + len("*/")
+		case blockRegex.MatchString(trimmed):
+			match := blockRegex.FindStringSubmatch(trimmed)
+			open := len(match[0]) - 1
+
+			end, err := findClosingBrace(trimmed, open)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", match[1], match[2], err)
+			}
+
+			items = append(items, &protoItem{
+				Kind:    match[1],
+				Name:    match[2],
+				Body:    trimmed[open+1 : end],
+				Comment: commentTracker,
+			})
+
+			commentTracker = ""
+			i = i + end + 1
+		default:
+			end, err := findStatementEnd(trimmed)
+			if err != nil {
+				return nil, err
+			}
+
+			item := &protoItem{
+				Statement: strings.TrimSpace(trimmed[:end]),
+				Comment:   commentTracker,
+			}
+			i = i + end + 1
+
+			// A comment on the same line as the statement belongs to it, not to the next one.
+			lineRest := input[i:]
+			if newline := strings.Index(lineRest, "\n"); newline != -1 {
+				lineRest = lineRest[:newline]
+			}
+
+			if trailing := strings.TrimSpace(lineRest); strings.HasPrefix(trailing, "//") {
+				if item.Comment == "" {
+					item.Comment = trailing
+				}
+
+				i = i + len(lineRest)
+			}
+
+			items = append(items, item)
+			commentTracker = ""
+		}
+	}
+
+	return items, nil
+}
+
+// findStatementEnd returns the index of the ";" ending the statement, skipping strings.
+func findStatementEnd(input string) (int, error) {
+	for i := 0; i < len(input); i++ {
+		switch input[i] {
+		case '"', '\'':
+			end := strings.IndexByte(input[i+1:], input[i])
+			if end == -1 {
+				return 0, fmt.Errorf("unterminated string: %s", input)
+			}
+			i = i + end + 1
+		case ';':
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("statement is missing a trailing \";\": %s", input)
+}
+
+// findClosingBrace returns the index of the brace closing the one at open, skipping comments and strings.
+func findClosingBrace(input string, open int) (int, error) {
+	depth := 0
+
+	for i := open; i < len(input); i++ {
+		switch {
+		case strings.HasPrefix(input[i:], "//"):
+			end := strings.Index(input[i:], "\n")
+			if end == -1 {
+				return 0, fmt.Errorf("missing closing brace")
+			}
+			i = i + end
+		case strings.HasPrefix(input[i:], "/*"):
+			end := strings.Index(input[i:], "*/")
+			if end == -1 {
+				return 0, fmt.Errorf("unterminated block comment")
+			}
+			i = i + end + 1
+		case input[i] == '"' || input[i] == '\'':
+			end := strings.IndexByte(input[i+1:], input[i])
+			if end == -1 {
+				return 0, fmt.Errorf("unterminated string")
+			}
+			i = i + end + 1
+		case input[i] == '{':
+			depth++
+		case input[i] == '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("missing closing brace")
+}
+
+// extractMessages takes the content of the file, and converts every message, nested ones included,
+// to a Message, along with the enums nested in them. Field types are left for resolveFieldTypes,
+// as they may refer to other files.
+func extractMessages(input string) ([]*Message, []*Enum, error) {
+	items, err := scanProtoItems(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := make([]*Message, 0)
+	enums := make([]*Enum, 0)
+
+	for _, item := range items {
+		if item.Kind != "message" {
+			continue
+		}
+
+		extracted, nested, err := extractMessage(item, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		messages = append(messages, extracted...)
+		enums = append(enums, nested...)
+	}
+
+	return messages, enums, nil
+}
+
+// extractMessage converts the message item and the messages and enums nested in it.
+func extractMessage(item *protoItem, parent *Message) ([]*Message, []*Enum, error) {
+	message := &Message{
+		Title:     item.Name,
+		ProtoName: item.Name,
+		FullName:  item.Name,
+		Comment:   item.Comment,
+	}
+
+	if parent != nil {
+		message.Title = parent.Title + item.Name
+		message.ProtoName = parent.ProtoName + "_" + item.Name
+		message.FullName = parent.FullName + "." + item.Name
+	}
+
+	items, err := scanProtoItems(item.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("message %s: %w", message.FullName, err)
+	}
+
+	messages := []*Message{message}
+	enums := make([]*Enum, 0)
+
+	for _, child := range items {
+		switch child.Kind {
+		case "":
+			field, err := extractField(child)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %s: %w", message.FullName, err)
+			}
+
+			if field != nil {
+				message.Fields = append(message.Fields, field)
+			}
+		case "oneof":
+			fields, err := extractOneof(child)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %s: %w", message.FullName, err)
+			}
+
+			message.Fields = append(message.Fields, fields...)
+		case "message":
+			nested, nestedEnums, err := extractMessage(child, message)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			messages = append(messages, nested...)
+			enums = append(enums, nestedEnums...)
+		case "enum":
+			enum, err := extractEnumItem(child, message)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %s: %w", message.FullName, err)
+			}
+
+			enums = append(enums, enum)
+		}
+	}
+
+	return messages, enums, nil
+}
+
+// extractOneof converts the fields of a oneof block, they all get the oneof name.
+func extractOneof(item *protoItem) ([]*Field, error) {
+	items, err := scanProtoItems(item.Body)
+	if err != nil {
+		return nil, fmt.Errorf("oneof %s: %w", item.Name, err)
+	}
+
+	fields := make([]*Field, 0, len(items))
+
+	for _, child := range items {
+		if child.Kind != "" {
+			continue
+		}
+
+		field, err := extractField(child)
+		if err != nil {
+			return nil, fmt.Errorf("oneof %s: %w", item.Name, err)
+		}
+
+		if field == nil {
+			continue
+		}
+
+		field.Oneof = item.Name
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// fieldRegex matches: [repeated|optional] (type|map<key, value>) name = number [options]
+var fieldRegex = regexp.MustCompile(
+	`^(?:(repeated|optional|required)\s+)?(?:map\s*<\s*([\w.]+)\s*,\s*([\w.]+)\s*>|([\w.]+))\s+(\w+)\s*=\s*(\w+)\s*(?:\[.*\])?$`)
+
+// extractField converts a field statement, it returns nil for statements that aren't fields (option, reserved...).
+func extractField(item *protoItem) (*Field, error) {
+	statement := strings.Join(strings.Fields(item.Statement), " ")
+
+	for _, keyword := range []string{"option ", "reserved ", "extensions "} {
+		if strings.HasPrefix(statement, keyword) {
+			return nil, nil
+		}
+	}
+
+	match := fieldRegex.FindStringSubmatch(statement)
+	if match == nil {
+		return nil, fmt.Errorf("field is expected to have this format: [repeated|optional] (Type) (name) = (Number), received content: %s",
+			statement)
+	}
+
+	number, err := strconv.ParseInt(match[6], 0, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the number of %s: %w", statement, err)
+	}
+
+	field := &Field{
+		OriginalName: match[5],
+		Name:         convertSnekToCamelCase(match[5]),
+		Label:        match[1],
+		ProtoType:    match[4],
+		Number:       int(number),
+		Comment:      item.Comment,
+	}
+
+	if match[2] != "" {
+		field.MapKey = match[2]
+		field.ProtoType = match[3]
+	}
+
+	return field, nil
+}
+
+// resolveFieldTypes sets the GoType of every field of the messages declared in the proto package pkg,
+// message and enum references are looked up in the visible ones.
+func resolveFieldTypes(pkg string, messages []*Message, visible *visibleTypes) error {
+	for _, message := range messages {
+		for _, field := range message.Fields {
+			valueType, err := resolveType(field.ProtoType, qualify(pkg, message.FullName), visible)
+			if err != nil {
+				return fmt.Errorf("message %s, field %s: %w", message.FullName, field.OriginalName, err)
+			}
+
+			switch {
+			case field.MapKey != "":
+				keyType, ok := scalarTypes[field.MapKey]
+				if !ok {
+					return fmt.Errorf("message %s, field %s: map key type %s is not a scalar",
+						message.FullName, field.OriginalName, field.MapKey)
+				}
+
+				field.GoType = fmt.Sprintf("map[%s]%s", keyType, valueType)
+			case field.Label == labelRepeated:
+				field.GoType = "[]" + valueType
+			case field.Label == labelOptional || field.Oneof != "":
+				// Presence has to be tracked, so scalars and enums become pointers.
+				field.GoType = pointerTo(valueType)
+			default:
+				field.GoType = valueType
+			}
+		}
+	}
+
+	return nil
+}
+
+// visibleTypes holds the messages and enums a proto file can refer to, by name with and without the proto package.
+type visibleTypes struct {
+	messages map[string]*Message
+	enums    map[string]*Enum
+}
+
+func newVisibleTypes() *visibleTypes {
+	return &visibleTypes{
+		messages: make(map[string]*Message),
+		enums:    make(map[string]*Enum),
+	}
+}
+
+// add registers the messages and enums declared in the proto package pkg.
+func (v *visibleTypes) add(pkg string, messages []*Message, enums []*Enum) {
+	for _, message := range messages {
+		v.messages[qualify(pkg, message.FullName)] = message
+	}
+
+	for _, enum := range enums {
+		v.enums[qualify(pkg, enum.GetFullName())] = enum
+	}
+}
+
+// resolveType returns the Go type of a single proto value, messages are pointers to their models.
+func resolveType(protoType string, scope string, visible *visibleTypes) (string, error) {
+	protoType = strings.TrimPrefix(protoType, ".")
+
+	if goType, ok := scalarTypes[protoType]; ok {
+		return goType, nil
+	}
+
+	if goType, ok := wellKnownTypes[protoType]; ok {
+		return goType, nil
+	}
+
+	if strings.HasPrefix(protoType, "google.protobuf.") {
+		return "", fmt.Errorf("well-known type %s is not supported", protoType)
+	}
+
+	// Look the name up from the innermost scope to the outermost, like protoc does.
+	for _, candidate := range typeCandidates(protoType, scope) {
+		if message, ok := visible.messages[candidate]; ok {
+			return "*" + message.Title, nil
+		}
+
+		if enum, ok := visible.enums[candidate]; ok {
+			return enum.Title, nil
+		}
+	}
+
+	return "", fmt.Errorf("unresolved type %s", protoType)
+}
+
+// qualify prefixes name with the proto package, if any.
+func qualify(pkg string, name string) string {
+	if pkg == "" {
+		return name
+	}
+
+	return pkg + "." + name
+}
+
+// typeCandidates lists the names protoType may refer to from scope, most specific first:
+// the enclosing scopes from the innermost to the root, like protoc. Qualified names such as
+// other.State are looked up the same way, so they never resolve to a type of another package.
+func typeCandidates(protoType string, scope string) []string {
+	candidates := make([]string, 0)
+
+	scopes := strings.Split(scope, ".")
+	for i := len(scopes); i > 0; i-- {
+		candidates = append(candidates, strings.Join(scopes[:i], ".")+"."+protoType)
+	}
+
+	return append(candidates, protoType)
+}
+
+// pointerTo returns the pointer type of goType, unless it's already nullable.
+func pointerTo(goType string) string {
+	if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+		return goType
+	}
+
+	return "*" + goType
+}
+
+// convertSnekToCamelCase converts snake_case to an unexported camelCase Go identifier.
+func convertSnekToCamelCase(input string) string {
+	pascal := convertSnekToPascalCase(strings.ToLower(input))
+	if pascal == "" {
+		return ""
+	}
+
+	camel := strings.ToLower(pascal[:1]) + pascal[1:]
+
+	// Keywords can't be used as field names.
+	if token.IsKeyword(camel) {
+		camel = camel + "_"
+	}
+
+	return camel
+}
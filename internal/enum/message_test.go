@@ -0,0 +1,104 @@
+package enum_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateFiles_Messages(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		file    string
+		wantErr bool
+	}{
+		// Nested, repeated, map, optional, oneof, enum and well-known type fields.
+		"Fields": {
+			file: "testdata/message/order.proto",
+		},
+		"UnknownType": {
+			file:    "testdata/message/unknown.proto",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := enum.LoadProtoFiles([]string{tt.file}, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error for the field of an unknown type")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				NameStyle: enum.NameStyleProto,
+				Layout:    enum.LayoutEnum,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
+
+// The wrapper fields of the models convert back to the wrappers once accessory runs on them.
+func TestGenerateFiles_MessageAccessors(t *testing.T) {
+	t.Parallel()
+
+	files, err := enum.LoadProtoFiles([]string{"testdata/message/wrappers.proto"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := enum.GenerateFiles(files, &enum.Options{
+		NameStyle: enum.NameStyleProto,
+		Layout:    enum.LayoutFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// accessory loads the models from disk, as a package of this module.
+	dir, err := os.MkdirTemp("testdata/message", "models")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	for _, output := range outputs {
+		if err := os.WriteFile(filepath.Join(dir, output.Name), output.Content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pkg, err := accessor.ParsePackage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := new(bytes.Buffer)
+	if err := accessor.Generate(afero.NewMemMapFs(), pkg, accessor.Type("Profile"), accessor.Sink(result)); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot.SnapshotT(t, result.String())
+}
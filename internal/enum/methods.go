@@ -0,0 +1,321 @@
+package enum
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Method families that can be requested with -with.
+const (
+	withString = "string"
+	withJSON   = "json"
+	withText   = "text"
+	withSQL    = "sql"
+	withYAML   = "yaml"
+)
+
+// Name styles used by String and Parse<Enum>.
+const (
+	// NameStyleProto keeps the proto name, e.g. REMINDER_STATE_STARTED.
+	NameStyleProto = "proto"
+	// NameStyleLower lowercases the proto name, e.g. reminder_state_started.
+	NameStyleLower = "lower"
+)
+
+// MethodFamilies is the set of method families to generate for every enum.
+type MethodFamilies map[string]bool
+
+// ParseMethodFamilies parses the comma separated -with value, e.g. "string,json".
+// Every family apart from string is built on top of String and Parse<Enum>,
+// so asking for any of them brings in the string family as well.
+func ParseMethodFamilies(input string) (MethodFamilies, error) {
+	families := make(MethodFamilies)
+
+	for _, family := range strings.Split(input, ",") {
+		family = strings.TrimSpace(family)
+
+		switch family {
+		case "":
+			continue
+		case withString, withJSON, withText, withSQL, withYAML:
+			families[family] = true
+			families[withString] = true
+		default:
+			return nil, fmt.Errorf("unknown method family %q, expected one of: %s",
+				family, strings.Join([]string{withString, withJSON, withText, withSQL, withYAML}, ","))
+		}
+	}
+
+	return families, nil
+}
+
+// String returns the families in a deterministic order.
+func (m MethodFamilies) String() string {
+	families := make([]string, 0, len(m))
+	for family := range m {
+		families = append(families, family)
+	}
+
+	sort.Strings(families)
+
+	return strings.Join(families, ",")
+}
+
+// ValidateNameStyle checks the -name-style value.
+func ValidateNameStyle(nameStyle string) error {
+	if nameStyle != NameStyleProto && nameStyle != NameStyleLower {
+		return fmt.Errorf("unknown name style %q, expected %s or %s", nameStyle, NameStyleProto, NameStyleLower)
+	}
+
+	return nil
+}
+
+// GetName returns the name used by String and Parse<Enum> for the Value.
+func (v *Value) GetName(nameStyle string) string {
+	if v == nil {
+		return ""
+	}
+
+	if nameStyle == NameStyleLower {
+		return strings.ToLower(v.OriginalStringValue)
+	}
+
+	return v.OriginalStringValue
+}
+
+// GenerateMethods generates the requested method families for the Enum.
+func (e *Enum) GenerateMethods(families MethodFamilies, nameStyle string) string {
+	if e == nil || !families[withString] {
+		return ""
+	}
+
+	result := e.stringMethods(nameStyle)
+
+	if families[withText] {
+		result = result + e.textMethods()
+	}
+
+	if families[withJSON] {
+		result = result + e.jsonMethods()
+	}
+
+	if families[withSQL] {
+		result = result + e.sqlMethods()
+	}
+
+	if families[withYAML] {
+		result = result + e.yamlMethods()
+	}
+
+	return result
+}
+
+// methodImports returns the packages the requested method families use.
+func (e *Enum) methodImports(families MethodFamilies) []string {
+	if e == nil || !families[withString] {
+		return nil
+	}
+
+	imports := []string{"fmt"}
+
+	if families[withJSON] {
+		imports = append(imports, "encoding/json")
+	}
+
+	if families[withSQL] {
+		imports = append(imports, "database/sql/driver")
+	}
+
+	return imports
+}
+
+// stringMethods generates the name lookup tables, <Enum>Values, String and Parse<Enum>.
+func (e *Enum) stringMethods(nameStyle string) string {
+	names := ""
+	values := ""
+	list := ""
+
+	for _, value := range e.GetValues() {
+		// Aliases share the number of the value they alias, so they can only be parsed,
+		// otherwise they would be duplicated keys.
+		if !value.IsAlias() {
+			names = names + fmt.Sprintf(`
+		%s: %q,`, value.StringValue, value.GetName(nameStyle))
+			list = list + fmt.Sprintf(`
+		%s,`, value.StringValue)
+		}
+
+		values = values + fmt.Sprintf(`
+		%q: %s,`, value.GetName(nameStyle), value.StringValue)
+	}
+
+	return fmt.Sprintf(`
+var (
+	%sNames = map[%s]string{%s
+	}
+
+	%sValues = map[string]%s{%s
+	}
+)
+
+// %sValues returns all the %s values, aliases excluded.
+func %sValues() []%s {
+	return []%s{%s
+	}
+}
+
+// String returns the name of the %s.
+func (%s %s) String() string {
+	if name, ok := %sNames[%s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("%s(%%d)", %s)
+}
+
+// Parse%s converts the name of a value back to the %s.
+func Parse%s(name string) (%s, error) {
+	if value, ok := %sValues[name]; ok {
+		return value, nil
+	}
+
+	return %s, fmt.Errorf("unknown %s: %%q", name)
+}`,
+		e.privateName(), e.Title, names,
+		e.privateName(), e.Title, values,
+		e.Title, e.Title, e.Title, e.Title, e.Title, list,
+		e.Title, e.GetReceiver(), e.Title, e.privateName(), e.GetReceiver(), e.Title, e.GetReceiver(),
+		e.Title, e.Title, e.Title, e.Title, e.privateName(), e.GetDefaultValue().GetStringValue(), e.Title)
+}
+
+func (e *Enum) textMethods() string {
+	return fmt.Sprintf(`
+
+// MarshalText implements encoding.TextMarshaler.
+func (%s %s) MarshalText() ([]byte, error) {
+	return []byte(%s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (%s *%s) UnmarshalText(text []byte) error {
+	value, err := Parse%s(string(text))
+	if err != nil {
+		return err
+	}
+
+	*%s = value
+
+	return nil
+}`, e.GetReceiver(), e.Title, e.GetReceiver(),
+		e.GetReceiver(), e.Title, e.Title, e.GetReceiver())
+}
+
+func (e *Enum) jsonMethods() string {
+	return fmt.Sprintf(`
+
+// MarshalJSON implements json.Marshaler.
+func (%s %s) MarshalJSON() ([]byte, error) {
+	return json.Marshal(%s.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (%s *%s) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	value, err := Parse%s(name)
+	if err != nil {
+		return err
+	}
+
+	*%s = value
+
+	return nil
+}`, e.GetReceiver(), e.Title, e.GetReceiver(),
+		e.GetReceiver(), e.Title, e.Title, e.GetReceiver())
+}
+
+func (e *Enum) sqlMethods() string {
+	return fmt.Sprintf(`
+
+// Value implements driver.Valuer.
+func (%s %s) Value() (driver.Value, error) {
+	return %s.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (%s *%s) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*%s = %s
+
+		return nil
+	case int64:
+		*%s = %s(src)
+
+		return nil
+	case []byte:
+		return %s.Scan(string(src))
+	case string:
+		value, err := Parse%s(src)
+		if err != nil {
+			return err
+		}
+
+		*%s = value
+
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %%T into %s", src)
+	}
+}`, e.GetReceiver(), e.Title, e.GetReceiver(),
+		e.GetReceiver(), e.Title,
+		e.GetReceiver(), e.GetDefaultValue().GetStringValue(),
+		e.GetReceiver(), e.Title,
+		e.GetReceiver(),
+		e.Title,
+		e.GetReceiver(),
+		e.Title)
+}
+
+// yamlMethods uses the unmarshal func signature, which both gopkg.in/yaml.v2 and v3 understand,
+// so the generated code doesn't need to import either of them.
+func (e *Enum) yamlMethods() string {
+	return fmt.Sprintf(`
+
+// MarshalYAML implements yaml.Marshaler.
+func (%s %s) MarshalYAML() (interface{}, error) {
+	return %s.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (%s *%s) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err != nil {
+		return err
+	}
+
+	value, err := Parse%s(name)
+	if err != nil {
+		return err
+	}
+
+	*%s = value
+
+	return nil
+}`, e.GetReceiver(), e.Title, e.GetReceiver(),
+		e.GetReceiver(), e.Title, e.Title, e.GetReceiver())
+}
+
+// privateName returns the Enum's Title with a lowercase first letter, used for unexported identifiers.
+func (e *Enum) privateName() string {
+	title := e.GetTitle()
+	if title == "" {
+		return ""
+	}
+
+	return strings.ToLower(title[:1]) + title[1:]
+}
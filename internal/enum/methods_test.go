@@ -0,0 +1,69 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateFiles_Methods(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		with      string
+		nameStyle string
+		layout    string
+	}{
+		"String": {
+			with:      "string",
+			nameStyle: enum.NameStyleProto,
+			layout:    enum.LayoutEnum,
+		},
+		"AllFamilies": {
+			with:      "text,json,sql,yaml",
+			nameStyle: enum.NameStyleLower,
+			layout:    enum.LayoutEnum,
+		},
+		"FileLayout": {
+			with:      "json,sql",
+			nameStyle: enum.NameStyleProto,
+			layout:    enum.LayoutFile,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			families, err := enum.ParseMethodFamilies(tt.with)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files, err := enum.LoadProtoFiles([]string{"testdata/text/common/state.proto"}, []string{"testdata/text"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				Families:  families,
+				NameStyle: tt.nameStyle,
+				Layout:    tt.layout,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
+
+func TestParseMethodFamilies_Unknown(t *testing.T) {
+	t.Parallel()
+
+	if _, err := enum.ParseMethodFamilies("string,xml"); err == nil {
+		t.Fatal("expected an error for the unknown method family")
+	}
+}
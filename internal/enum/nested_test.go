@@ -0,0 +1,35 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestLoadProtoFiles_Nested(t *testing.T) {
+	t.Parallel()
+
+	files, err := enum.LoadProtoFiles([]string{"testdata/nested/reminder.proto"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := enum.GenerateFiles(files, &enum.Options{
+		NameStyle: enum.NameStyleProto,
+		Layout:    enum.LayoutFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshotOutputs(t, outputs)
+}
+
+func TestLoadProtoFiles_UnresolvedQualifiedType(t *testing.T) {
+	t.Parallel()
+
+	_, err := enum.LoadProtoFiles([]string{"testdata/nested/unresolved.proto"}, nil)
+	if err == nil {
+		t.Fatal("expected an error for the type of the unknown package")
+	}
+}
@@ -0,0 +1,68 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateFiles_Numbers(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		file    string
+		wantErr bool
+	}{
+		// Explicit numbers out of order, an alias and the hex, octal and negative literals.
+		"Explicit": {
+			file: "testdata/numbers/priority.proto",
+		},
+		"DuplicateWithoutAlias": {
+			file:    "testdata/numbers/duplicate.proto",
+			wantErr: true,
+		},
+		// Go accepts these literals, proto doesn't.
+		"Binary": {
+			file:    "testdata/numbers/binary.proto",
+			wantErr: true,
+		},
+		"OctalPrefix": {
+			file:    "testdata/numbers/octal_prefix.proto",
+			wantErr: true,
+		},
+		"Underscore": {
+			file:    "testdata/numbers/underscore.proto",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := enum.LoadProtoFiles([]string{tt.file}, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error loading %s", tt.file)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				NameStyle: enum.NameStyleProto,
+				Layout:    enum.LayoutFile,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package enum
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Keys of the plugin parameter, given as --accessory-enum_opt=key=value,key=value.
+const (
+	paramWith         = "with"
+	paramNameStyle    = "name_style"
+	paramStrict       = "strict"
+	paramLayout       = "layout"
+	paramProtoPackage = "proto_package"
+	paramModelPackage = "model_package"
+	paramPackage      = "package"
+	paramFlags        = "flags"
+	paramEmit         = "emit"
+	paramTestStyle    = "test_style"
+	paramFuzz         = "fuzz"
+)
+
+// ParsePluginParameter parses the parameter protoc passes to the plugin, e.g.
+//
+//	proto_package=deliveryv1,model_package=models,with=string+json,strict=true
+//
+// Method families, the enums given to flags and the emitters are separated with "+",
+// as "," separates the parameters.
+func ParsePluginParameter(parameter string) (*Options, error) {
+	opts := &Options{
+		Families:  make(MethodFamilies),
+		NameStyle: NameStyleProto,
+		Layout:    LayoutEnum,
+	}
+
+	for _, param := range strings.Split(parameter, ",") {
+		if strings.TrimSpace(param) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return nil, fmt.Errorf("parameter %q is expected to have this format: key=value", param)
+		}
+
+		switch strings.TrimSpace(key) {
+		case paramWith:
+			families, err := ParseMethodFamilies(strings.ReplaceAll(value, "+", ","))
+			if err != nil {
+				return nil, err
+			}
+
+			opts.Families = families
+		case paramNameStyle:
+			opts.NameStyle = value
+		case paramStrict:
+			strict, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", paramStrict, err)
+			}
+
+			opts.Strict = strict
+		case paramLayout:
+			opts.Layout = value
+		case paramProtoPackage:
+			opts.ProtoPackage = value
+		case paramModelPackage:
+			opts.ModelPackage = value
+		case paramPackage:
+			opts.Package = value
+		case paramFlags:
+			opts.Flags = ParseFlagsEnums(strings.ReplaceAll(value, "+", ","))
+		case paramEmit:
+			emitters, err := ParseEmitters(strings.ReplaceAll(value, "+", ","))
+			if err != nil {
+				return nil, err
+			}
+
+			opts.Emit = emitters
+		case paramTestStyle:
+			opts.TestStyle = value
+		case paramFuzz:
+			fuzz, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", paramFuzz, err)
+			}
+
+			opts.Fuzz = fuzz
+		default:
+			return nil, fmt.Errorf("unknown parameter %q", key)
+		}
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// RunPlugin reads a CodeGeneratorRequest from r and writes the CodeGeneratorResponse to w,
+// which is how protoc and buf talk to protoc-gen-accessory-enum.
+func RunPlugin(r io.Reader, w io.Writer) error {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(input, req); err != nil {
+		return fmt.Errorf("failed to read the CodeGeneratorRequest: %w", err)
+	}
+
+	output, err := proto.Marshal(HandlePluginRequest(req))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(output)
+
+	return err
+}
+
+// HandlePluginRequest generates the enums and the models of the files to generate of the request.
+// Generation errors are reported in the response, so protoc can show them.
+func HandlePluginRequest(req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
+	resp := &pluginpb.CodeGeneratorResponse{
+		SupportedFeatures: proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)),
+	}
+
+	outputs, err := generatePluginFiles(req)
+	if err != nil {
+		resp.Error = proto.String(err.Error())
+
+		return resp
+	}
+
+	for _, output := range outputs {
+		resp.File = append(resp.File, &pluginpb.CodeGeneratorResponse_File{
+			Name:    proto.String(output.Name),
+			Content: proto.String(string(output.Content)),
+		})
+	}
+
+	return resp
+}
+
+func generatePluginFiles(req *pluginpb.CodeGeneratorRequest) ([]*OutputFile, error) {
+	opts, err := ParsePluginParameter(req.GetParameter())
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := protoFilesFromDescriptors(req.GetProtoFile(), req.GetFileToGenerate())
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateFiles(files, opts)
+}
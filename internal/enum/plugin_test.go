@@ -0,0 +1,67 @@
+package enum_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+// The requests in testdata/plugin are what protoc sends to the plugin for testdata/plugin/reminder.proto,
+// with the parameter named after the file.
+func TestRunPlugin(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		request string
+	}{
+		"Default": {
+			request: "testdata/plugin/default.pb",
+		},
+		"WithParameters": {
+			request: "testdata/plugin/with_parameters.pb",
+		},
+		"InvalidParameter": {
+			request: "testdata/plugin/invalid_parameter.pb",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			input, err := os.ReadFile(filepath.FromSlash(tt.request))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output := new(bytes.Buffer)
+			if err := enum.RunPlugin(bytes.NewReader(input), output); err != nil {
+				t.Fatal(err)
+			}
+
+			resp := &pluginpb.CodeGeneratorResponse{}
+			if err := proto.Unmarshal(output.Bytes(), resp); err != nil {
+				t.Fatal(err)
+			}
+
+			result := new(bytes.Buffer)
+			if resp.Error != nil {
+				fmt.Fprintf(result, "error: %s\n", resp.GetError())
+			}
+
+			for _, file := range resp.GetFile() {
+				fmt.Fprintf(result, "==> %s\n%s\n", file.GetName(), file.GetContent())
+			}
+
+			snapshot.SnapshotT(t, result.String())
+		})
+	}
+}
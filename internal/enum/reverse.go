@@ -0,0 +1,304 @@
+package enum
+
+import (
+	"fmt"
+	"go/format"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+// ReverseOptions holds what the reverse mode needs on top of the Go package.
+type ReverseOptions struct {
+	// GoPackage is the go_package option of the generated proto file,
+	// the import path of the Go code protoc-gen-go generates from it, e.g. example.com/gen/order/v1;orderv1.
+	GoPackage string
+	// ProtoPackage is the package clause of the generated proto file, the name of the Go package if empty.
+	ProtoPackage string
+	// TestStyle is the style of the generated test, testgen.StyleGt if empty. The test file is left out with testgen.StyleNone, unless Fuzz is set.
+	TestStyle string
+	// Fuzz adds a fuzz test of the conversions of every enum to the test file.
+	Fuzz bool
+}
+
+// GenerateReverseFiles generates a proto3 file with an enum for every named integer type of the Go package,
+// and the conversions between the Go types and the enums protoc-gen-go generates from it:
+//
+//	<package>.proto          the enums, prefixed with the type name and starting with <TYPE>_UNSPECIFIED = 0
+//	<package>_proto.go       ToProto and ProtoTo<Type>, to be put in the Go package
+//	<package>_proto_test.go  the round trip test of every value
+func GenerateReverseFiles(pkg *accessor.Package, opts *ReverseOptions) ([]*OutputFile, error) {
+	if opts.GoPackage == "" {
+		return nil, fmt.Errorf("the go_package of the generated proto file is required")
+	}
+
+	testStyle := opts.TestStyle
+	if testStyle == "" {
+		testStyle = testgen.StyleGt
+	}
+
+	if err := testgen.Validate(testStyle); err != nil {
+		return nil, err
+	}
+
+	if len(pkg.Enums) == 0 {
+		return nil, fmt.Errorf("no named integer type with constants found in %s", pkg.PkgPath)
+	}
+
+	protoGoPackage := goPackageNameOf(opts.GoPackage)
+	protoImport, _, _ := strings.Cut(opts.GoPackage, ";")
+
+	enums := make([]*Enum, 0, len(pkg.Enums))
+	for _, goEnum := range pkg.Enums {
+		enum, err := enumFromGo(goEnum)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pkg.PkgPath, err)
+		}
+
+		enum.ProtoPackage = protoGoPackage
+		enum.ModelPackage = pkg.Name
+		enums = append(enums, enum)
+	}
+
+	protoPackage := opts.ProtoPackage
+	if protoPackage == "" {
+		protoPackage = pkg.Name
+	}
+
+	definition := fmt.Sprintf("// Code generated by accessory from %s; DO NOT EDIT.\n\nsyntax = \"proto3\";\n\npackage %s;\n\noption go_package = %q;\n",
+		pkg.PkgPath, protoPackage, opts.GoPackage)
+
+	conversions := fmt.Sprintf("// Code generated by accessory; DO NOT EDIT.\n\npackage %s\n\nimport %s %q\n",
+		pkg.Name, protoGoPackage, protoImport)
+
+	imports := testgen.Imports(testStyle)
+	if opts.Fuzz && len(imports) == 0 {
+		imports = []string{"testing"}
+	}
+
+	tests := fmt.Sprintf("// Code generated by accessory; DO NOT EDIT.\n\npackage %s_test\n\nimport (\n%s\t%q\n\t%s %q\n)\n",
+		pkg.Name, testImports(imports), pkg.PkgPath, protoGoPackage, protoImport)
+
+	for _, enum := range enums {
+		definition = definition + "\n" + enum.ToProtoDefinition()
+		conversions = conversions + enum.ToProto() + "\n" + enum.ProtoToEnum() + "\n"
+
+		test, err := enum.GenerateTest(testStyle)
+		if err != nil {
+			return nil, err
+		}
+
+		tests = tests + test
+		if opts.Fuzz {
+			tests = tests + enum.GenerateFuzzTest()
+		}
+	}
+
+	src, err := format.Source([]byte(conversions))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the conversions of %s: %w", pkg.PkgPath, err)
+	}
+
+	outputs := []*OutputFile{
+		{Name: pkg.Name + ".proto", Content: []byte(definition)},
+		{Name: pkg.Name + "_proto.go", Content: src},
+	}
+
+	if testStyle != testgen.StyleNone || opts.Fuzz {
+		outputs = append(outputs, &OutputFile{Name: pkg.Name + "_proto_test.go", Content: []byte(tests)})
+	}
+
+	return outputs, nil
+}
+
+// testImports are the import lines of the packages the tests need,
+// the standard library ones first and each group followed by a blank line.
+func testImports(imports []string) string {
+	var std, others string
+	for _, imp := range imports {
+		if strings.Contains(imp, ".") {
+			others = others + fmt.Sprintf("\t%q\n", imp)
+		} else {
+			std = std + fmt.Sprintf("\t%q\n", imp)
+		}
+	}
+
+	if others != "" {
+		others = others + "\n"
+	}
+
+	return std + "\n" + others
+}
+
+// enumFromGo converts a Go enum. Value names are the UPPER_SNAKE constant names prefixed with the type name,
+// StatusPaid and Paid both give STATUS_PAID. Numbers are the constant values, unless a constant other than
+// <TYPE>_UNSPECIFIED is zero: proto3 reserves zero for the unspecified value, every number is then shifted
+// so the smallest one is 1. The conversions go through the names, so the numbers don't have to match.
+func enumFromGo(goEnum *accessor.Enum) (*Enum, error) {
+	prefix := toUpperSnake(goEnum.Name)
+	unspecified := prefix + "_UNSPECIFIED"
+
+	enum := &Enum{
+		Title:   goEnum.Name,
+		Comment: goEnum.Comment,
+	}
+
+	var (
+		min       int64 = math.MaxInt64
+		zeroTaken bool
+		declared  *Value
+		numbers   = make(map[int64]bool)
+	)
+
+	for _, constant := range goEnum.Constants {
+		if constant.Value < min {
+			min = constant.Value
+		}
+
+		if constant.Value == 0 && protoValueName(prefix, constant.Name) != unspecified {
+			zeroTaken = true
+		}
+
+		if numbers[constant.Value] {
+			enum.AllowAlias = true
+		}
+
+		numbers[constant.Value] = true
+	}
+
+	var shift int64
+	if zeroTaken {
+		shift = 1 - min
+	}
+
+	values := make([]*Value, 0, len(goEnum.Constants))
+	for _, constant := range goEnum.Constants {
+		number := constant.Value + shift
+		if number < math.MinInt32 || number > math.MaxInt32 {
+			return nil, fmt.Errorf("%s = %d is out of the range of proto enum numbers", constant.Name, constant.Value)
+		}
+
+		value := &Value{
+			OriginalStringValue: protoValueName(prefix, constant.Name),
+			StringValue:         constant.Name,
+			NumberValue:         int(number),
+			Comment:             constant.Comment,
+			Deprecated:          isDeprecatedComment(constant.Comment),
+		}
+
+		if value.OriginalStringValue == unspecified && number == 0 {
+			declared = value
+		}
+
+		values = append(values, value)
+	}
+
+	if err := setValues(enum, values); err != nil {
+		return nil, err
+	}
+
+	// Without an unspecified constant, proto zero converts to the zero value of the Go type,
+	// and Go values without constant convert to proto zero.
+	enum.DefaultValue = declared
+	if enum.DefaultValue == nil {
+		enum.DefaultValue = &Value{
+			OriginalStringValue: unspecified,
+			StringValue:         fmt.Sprintf("%s(0)", goEnum.Name),
+		}
+	}
+
+	return enum, nil
+}
+
+// ToProtoDefinition generates the proto3 enum, the unspecified value first.
+func (e *Enum) ToProtoDefinition() string {
+	if e == nil {
+		return ""
+	}
+
+	result := ""
+	if e.GetComment() != "" {
+		result = e.GetComment() + "\n"
+	}
+
+	result = result + fmt.Sprintf("enum %s {\n", e.GetTitle())
+
+	if e.GetAllowAlias() {
+		result = result + "  option allow_alias = true;\n"
+	}
+
+	if !e.hasValue(e.GetDefaultValue()) {
+		result = result + fmt.Sprintf("  %s = 0;\n", e.GetDefaultValue().GetOriginalStringValue())
+	}
+
+	for _, value := range e.GetValues() {
+		for _, line := range strings.Split(value.GetComment(), "\n") {
+			if line != "" {
+				result = result + "  " + line + "\n"
+			}
+		}
+
+		options := ""
+		if value.GetDeprecated() {
+			options = " [deprecated = true]"
+		}
+
+		result = result + fmt.Sprintf("  %s = %d%s;\n", value.GetOriginalStringValue(), value.GetNumberValue(), options)
+	}
+
+	return result + "}\n"
+}
+
+func (e *Enum) hasValue(value *Value) bool {
+	for _, v := range e.GetValues() {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// protoValueName is the UPPER_SNAKE name of the constant, prefixed with the type name unless it already is.
+func protoValueName(prefix, constant string) string {
+	name := toUpperSnake(constant)
+	if name == prefix || strings.HasPrefix(name, prefix+"_") {
+		return name
+	}
+
+	return prefix + "_" + name
+}
+
+// toUpperSnake converts a Go name to UPPER_SNAKE: HTTPStatusOK => HTTP_STATUS_OK.
+func toUpperSnake(input string) string {
+	var result strings.Builder
+
+	runes := []rune(input)
+	for i, r := range runes {
+		if i > 0 && r != '_' && runes[i-1] != '_' {
+			// A word starts at an upper case letter following a lower case letter or a digit,
+			// or at the last upper case letter of an acronym.
+			if unicode.IsUpper(r) && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				result.WriteRune('_')
+			}
+		}
+
+		result.WriteRune(unicode.ToUpper(r))
+	}
+
+	return result.String()
+}
+
+// isDeprecatedComment reports whether the Go doc comment has a "Deprecated:" paragraph.
+func isDeprecatedComment(comment string) bool {
+	for _, line := range commentLines(comment) {
+		if strings.HasPrefix(line, "Deprecated:") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,464 @@
+package enum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/internal/accessor"
+)
+
+// Field numbers 19000 to 19999 are reserved for the protobuf implementation.
+const (
+	firstReservedFieldNumber = 19000
+	lastReservedFieldNumber  = 19999
+)
+
+// goScalarTypes maps the Go basic types to the proto scalar types, the reverse of scalarTypes.
+var goScalarTypes = map[types.BasicKind]string{
+	types.Bool:    "bool",
+	types.String:  "string",
+	types.Int:     "int64",
+	types.Int8:    "int32",
+	types.Int16:   "int32",
+	types.Int32:   "int32",
+	types.Int64:   "int64",
+	types.Uint:    "uint64",
+	types.Uint8:   "uint32",
+	types.Uint16:  "uint32",
+	types.Uint32:  "uint32",
+	types.Uint64:  "uint64",
+	types.Float32: "float",
+	types.Float64: "double",
+}
+
+// goWellKnownTypes maps the Go types with a well-known proto counterpart to it and its import.
+var goWellKnownTypes = map[string][2]string{
+	"time.Time":     {"google.protobuf.Timestamp", "google/protobuf/timestamp.proto"},
+	"time.Duration": {"google.protobuf.Duration", "google/protobuf/duration.proto"},
+}
+
+// FieldLock persists the field numbers given to the struct fields, so generating the messages again
+// never renumbers them. Numbers and names of removed fields are kept as reserved.
+type FieldLock struct {
+	Messages map[string]*MessageLock `json:"messages"`
+}
+
+type MessageLock struct {
+	Fields map[string]int32 `json:"fields"`
+	// Removed are the fields the struct doesn't have anymore, their names and numbers are reserved.
+	Removed map[string]int32 `json:"removed,omitempty"`
+}
+
+// LoadFieldLock reads the lock file from fs, an empty lock if it doesn't exist yet.
+func LoadFieldLock(fs afero.Fs, path string) (*FieldLock, error) {
+	lock := &FieldLock{Messages: make(map[string]*MessageLock)}
+
+	content, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, fmt.Errorf("failed to read the lock file %s: %w", path, err)
+	}
+
+	if lock.Messages == nil {
+		lock.Messages = make(map[string]*MessageLock)
+	}
+
+	return lock, nil
+}
+
+// Marshal returns the content of the lock file.
+func (l *FieldLock) Marshal() ([]byte, error) {
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+// number returns the number of the field, a new one if the field isn't locked yet.
+// A field that was removed and comes back gets its number back.
+func (m *MessageLock) number(field string) int32 {
+	if number, ok := m.Fields[field]; ok {
+		return number
+	}
+
+	if number, ok := m.Removed[field]; ok {
+		delete(m.Removed, field)
+		m.Fields[field] = number
+
+		return number
+	}
+
+	used := make(map[int32]bool)
+	for _, number := range m.Fields {
+		used[number] = true
+	}
+
+	for _, number := range m.Removed {
+		used[number] = true
+	}
+
+	var next int32 = 1
+	for used[next] || (next >= firstReservedFieldNumber && next <= lastReservedFieldNumber) {
+		next++
+	}
+
+	m.Fields[field] = next
+
+	return next
+}
+
+// release moves the locked fields the message doesn't have anymore to the removed ones.
+func (m *MessageLock) release(current map[string]bool) {
+	for name, number := range m.Fields {
+		if current[name] {
+			continue
+		}
+
+		if m.Removed == nil {
+			m.Removed = make(map[string]int32)
+		}
+
+		m.Removed[name] = number
+		delete(m.Fields, name)
+	}
+}
+
+// reserved generates the reserved statements of the removed fields.
+func (m *MessageLock) reserved() string {
+	if len(m.Removed) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(m.Removed))
+	numbers := make([]int, 0, len(m.Removed))
+
+	for name, number := range m.Removed {
+		names = append(names, name)
+		numbers = append(numbers, int(number))
+	}
+
+	sort.Strings(names)
+	sort.Ints(numbers)
+
+	quotedNames := make([]string, 0, len(names))
+	for _, name := range names {
+		quotedNames = append(quotedNames, fmt.Sprintf("%q", name))
+	}
+
+	numberList := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		numberList = append(numberList, fmt.Sprint(number))
+	}
+
+	return fmt.Sprintf("  reserved %s;\n  reserved %s;\n\n", strings.Join(numberList, ", "), strings.Join(quotedNames, ", "))
+}
+
+// MessageOptions holds what GenerateProtoMessages needs on top of the Go package.
+type MessageOptions struct {
+	// Types are the structs to generate a message for, the structs and enums of the package
+	// they refer to are generated as well.
+	Types []string
+	// GoPackage is the go_package option of the generated proto file.
+	GoPackage string
+	// ProtoPackage is the package clause of the generated proto file, the name of the Go package if empty.
+	ProtoPackage string
+}
+
+// protoMessageBuilder collects the messages and enums, in the order they are found.
+type protoMessageBuilder struct {
+	pkg     *accessor.Package
+	lock    *FieldLock
+	structs map[string]*accessor.Struct
+	enums   map[string]*accessor.Enum
+
+	queue    []string
+	queued   map[string]bool
+	enumRefs []string
+	imports  map[string]bool
+}
+
+// GenerateProtoMessages generates a proto3 file with a message for each struct of the options,
+// the field numbers come from the lock, which is updated with the new and removed fields.
+//
+//	[]T, map[K]V  repeated T, map<K, V>
+//	*T            optional T for scalars, T for structs
+//	time.Time     google.protobuf.Timestamp
+//	Struct, Enum  the message, or the enum, generated from the type of the package
+func GenerateProtoMessages(pkg *accessor.Package, lock *FieldLock, opts *MessageOptions) ([]byte, error) {
+	if opts.GoPackage == "" {
+		return nil, fmt.Errorf("the go_package of the generated proto file is required")
+	}
+
+	if len(opts.Types) == 0 {
+		return nil, fmt.Errorf("at least one struct is required")
+	}
+
+	b := &protoMessageBuilder{
+		pkg:     pkg,
+		lock:    lock,
+		structs: make(map[string]*accessor.Struct),
+		enums:   make(map[string]*accessor.Enum),
+		queued:  make(map[string]bool),
+		imports: make(map[string]bool),
+	}
+
+	for _, st := range pkg.Structs {
+		b.structs[st.Name] = st
+	}
+
+	for _, enum := range pkg.Enums {
+		b.enums[enum.Name] = enum
+	}
+
+	for _, name := range opts.Types {
+		if _, ok := b.structs[name]; !ok {
+			return nil, fmt.Errorf("struct %s not found in %s", name, pkg.PkgPath)
+		}
+
+		b.enqueue(name)
+	}
+
+	messages := ""
+	for i := 0; i < len(b.queue); i++ {
+		message, err := b.message(b.structs[b.queue[i]])
+		if err != nil {
+			return nil, err
+		}
+
+		messages = messages + "\n" + message
+	}
+
+	for _, name := range b.enumRefs {
+		enum, err := enumFromGo(b.enums[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pkg.PkgPath, err)
+		}
+
+		messages = messages + "\n" + enum.ToProtoDefinition()
+	}
+
+	protoPackage := opts.ProtoPackage
+	if protoPackage == "" {
+		protoPackage = pkg.Name
+	}
+
+	result := fmt.Sprintf("// Code generated by accessory from %s; DO NOT EDIT.\n\nsyntax = \"proto3\";\n\npackage %s;\n",
+		pkg.PkgPath, protoPackage)
+
+	if len(b.imports) > 0 {
+		imports := make([]string, 0, len(b.imports))
+		for imp := range b.imports {
+			imports = append(imports, imp)
+		}
+
+		sort.Strings(imports)
+
+		result = result + "\n"
+		for _, imp := range imports {
+			result = result + fmt.Sprintf("import %q;\n", imp)
+		}
+	}
+
+	return []byte(result + fmt.Sprintf("\noption go_package = %q;\n", opts.GoPackage) + messages), nil
+}
+
+func (b *protoMessageBuilder) enqueue(name string) {
+	if b.queued[name] {
+		return
+	}
+
+	b.queued[name] = true
+	b.queue = append(b.queue, name)
+}
+
+// message generates the message of the struct and locks its field numbers.
+func (b *protoMessageBuilder) message(st *accessor.Struct) (string, error) {
+	messageLock, ok := b.lock.Messages[st.Name]
+	if !ok {
+		messageLock = &MessageLock{Fields: make(map[string]int32)}
+		b.lock.Messages[st.Name] = messageLock
+	}
+
+	if messageLock.Fields == nil {
+		messageLock.Fields = make(map[string]int32)
+	}
+
+	fields := ""
+	current := make(map[string]bool)
+
+	for _, field := range st.Fields {
+		if isLockType(field.Type) {
+			continue
+		}
+
+		if field.Embedded {
+			return "", fmt.Errorf("%s.%s: embedded fields have no proto counterpart", st.Name, field.Name)
+		}
+
+		protoType, err := b.fieldType(field.Type)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", st.Name, field.Name, err)
+		}
+
+		name := strings.ToLower(toUpperSnake(field.Name))
+		current[name] = true
+		fields = fields + fmt.Sprintf("  %s %s = %d;\n", protoType, name, messageLock.number(name))
+	}
+
+	messageLock.release(current)
+
+	result := ""
+	if st.Comment != "" {
+		result = st.Comment + "\n"
+	}
+
+	result = result + fmt.Sprintf("message %s {\n", st.Name)
+
+	result = result + messageLock.reserved()
+
+	return result + fields + "}\n", nil
+}
+
+// fieldType returns the proto type of a field, with its label.
+func (b *protoMessageBuilder) fieldType(typ types.Type) (string, error) {
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		if isByte(t.Elem()) {
+			return "bytes", nil
+		}
+
+		elem, err := b.elemType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+
+		return "repeated " + elem, nil
+	case *types.Map:
+		key, ok := t.Key().Underlying().(*types.Basic)
+		if !ok || key.Info()&(types.IsInteger|types.IsString|types.IsBoolean) == 0 {
+			return "", fmt.Errorf("map keys must be integers, strings or booleans, not %s", t.Key())
+		}
+
+		value, err := b.elemType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("map<%s, %s>", goScalarTypes[key.Kind()], value), nil
+	case *types.Pointer:
+		elem, err := b.elemType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+
+		// Pointers tell unset from zero, proto3 does the same for scalars with optional.
+		if _, ok := t.Elem().Underlying().(*types.Basic); ok && !b.isEnum(t.Elem()) {
+			return "optional " + elem, nil
+		}
+
+		return elem, nil
+	}
+
+	return b.elemType(typ)
+}
+
+// elemType returns the proto type of a single value, which can't be repeated or a map.
+func (b *protoMessageBuilder) elemType(typ types.Type) (string, error) {
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+
+	if named, ok := typ.(*types.Named); ok {
+		name := named.Obj().Name()
+		if named.Obj().Pkg() != nil {
+			name = named.Obj().Pkg().Path() + "." + name
+		}
+
+		if wellKnown, ok := goWellKnownTypes[name]; ok {
+			b.imports[wellKnown[1]] = true
+
+			return wellKnown[0], nil
+		}
+
+		if named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == b.pkg.PkgPath {
+			if _, ok := b.structs[named.Obj().Name()]; ok {
+				b.enqueue(named.Obj().Name())
+
+				return named.Obj().Name(), nil
+			}
+
+			if b.isEnum(named) {
+				b.addEnum(named.Obj().Name())
+
+				return named.Obj().Name(), nil
+			}
+		}
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		if scalar, ok := goScalarTypes[t.Kind()]; ok {
+			return scalar, nil
+		}
+	case *types.Slice:
+		if isByte(t.Elem()) {
+			return "bytes", nil
+		}
+	}
+
+	return "", fmt.Errorf("%s has no proto counterpart", typ)
+}
+
+func (b *protoMessageBuilder) isEnum(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != b.pkg.PkgPath {
+		return false
+	}
+
+	_, ok = b.enums[named.Obj().Name()]
+
+	return ok
+}
+
+func (b *protoMessageBuilder) addEnum(name string) {
+	for _, ref := range b.enumRefs {
+		if ref == name {
+			return
+		}
+	}
+
+	b.enumRefs = append(b.enumRefs, name)
+}
+
+func isByte(typ types.Type) bool {
+	basic, ok := typ.(*types.Basic)
+
+	return ok && basic.Kind() == types.Byte
+}
+
+// isLockType reports whether the field is a sync lock, like the ones accessory -lock uses, which isn't data.
+func isLockType(typ types.Type) bool {
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "sync"
+}
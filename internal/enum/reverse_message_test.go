@@ -0,0 +1,77 @@
+package enum_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateProtoMessages(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		lockFile string
+	}{
+		"NewLock": {
+			lockFile: "testdata/reverse/shop/missing.proto.lock",
+		},
+		// The lock keeps the numbers of id, status and items, reserves the removed note
+		// and gives ttl its number back.
+		"ExistingLock": {
+			lockFile: "testdata/reverse/shop/shop.proto.lock",
+		},
+	}
+
+	pkg, err := accessor.ParsePackage("testdata/reverse/shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			lock, err := enum.LoadFieldLock(afero.NewOsFs(), tt.lockFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			content, err := enum.GenerateProtoMessages(pkg, lock, &enum.MessageOptions{
+				Types:     []string{"Order"},
+				GoPackage: "example.com/gen/shop/v1;shopv1",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lockContent, err := lock.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot.SnapshotT(t, fmt.Sprintf("==> shop.proto\n%s\n==> shop.proto.lock\n%s", content, lockContent))
+		})
+	}
+}
+
+func TestGenerateProtoMessages_UnknownStruct(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := accessor.ParsePackage("testdata/reverse/shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = enum.GenerateProtoMessages(pkg, &enum.FieldLock{Messages: map[string]*enum.MessageLock{}}, &enum.MessageOptions{
+		Types:     []string{"Cart"},
+		GoPackage: "example.com/gen/shop/v1;shopv1",
+	})
+	if err == nil {
+		t.Fatal("expected an error for the unknown struct")
+	}
+}
@@ -0,0 +1,40 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/accessor"
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateReverseFiles(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := accessor.ParsePackage("testdata/reverse/order")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := enum.GenerateReverseFiles(pkg, &enum.ReverseOptions{
+		GoPackage:    "example.com/gen/order/v1;orderv1",
+		ProtoPackage: "order.v1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshotOutputs(t, outputs)
+}
+
+func TestGenerateReverseFiles_MissingGoPackage(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := accessor.ParsePackage("testdata/reverse/order")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := enum.GenerateReverseFiles(pkg, &enum.ReverseOptions{}); err == nil {
+		t.Fatal("expected an error without go_package")
+	}
+}
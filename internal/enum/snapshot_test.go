@@ -0,0 +1,27 @@
+package enum_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+var snapshot = cupaloy.New(
+	cupaloy.SnapshotSubdirectory("testdata/.snapshots"),
+)
+
+// snapshotOutputs snapshots the generated files one after the other, each headed by its name.
+func snapshotOutputs(t *testing.T, outputs []*enum.OutputFile) {
+	t.Helper()
+
+	result := new(bytes.Buffer)
+	for _, output := range outputs {
+		fmt.Fprintf(result, "==> %s\n%s\n", output.Name, output.Content)
+	}
+
+	snapshot.SnapshotT(t, result.String())
+}
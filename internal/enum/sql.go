@@ -0,0 +1,241 @@
+package enum
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SQLMigrationFile is the name of the migration written when the previous DDL is given.
+const SQLMigrationFile = "migration.sql"
+
+var (
+	// createTypeRegex matches the CREATE TYPE statements of the previous DDL:
+	// CREATE TYPE reminder_state AS ENUM ('REMINDER_STATE_STARTED', ...);
+	createTypeRegex = regexp.MustCompile(`(?is)CREATE\s+TYPE\s+"?([\w.]+)"?\s+AS\s+ENUM\s*\(([^)]*)\)`)
+	// addValueRegex matches the values added by earlier migrations, if they are part of the previous DDL.
+	addValueRegex = regexp.MustCompile(`(?is)ALTER\s+TYPE\s+"?([\w.]+)"?\s+ADD\s+VALUE\s+(?:IF\s+NOT\s+EXISTS\s+)?('(?:[^']|'')*')`)
+	// sqlLabelRegex matches a quoted enum label, quotes are escaped by doubling them.
+	sqlLabelRegex = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
+// SQLTypeName is the name of the Postgres enum type of the Enum, in lower snake case: ReminderState => reminder_state.
+func (e *Enum) SQLTypeName() string {
+	if e == nil {
+		return ""
+	}
+
+	return strings.ToLower(toUpperSnake(e.GetTitle()))
+}
+
+// sqlLabels returns the labels of the Postgres enum, the names String writes to the database with the sql family.
+func (e *Enum) sqlLabels(nameStyle string) []string {
+	labels := make([]string, 0, len(e.GetValues()))
+
+	for _, value := range e.GetValues() {
+		if value.IsAlias() {
+			continue
+		}
+
+		labels = append(labels, value.GetName(nameStyle))
+	}
+
+	return labels
+}
+
+// ToSQL generates the CREATE TYPE statement of the Enum, with its comment.
+func (e *Enum) ToSQL(nameStyle string) string {
+	if e == nil {
+		return ""
+	}
+
+	result := ""
+	for _, line := range commentLines(e.GetComment()) {
+		result = result + strings.TrimRight("-- "+line, " ") + "\n"
+	}
+
+	labels := e.sqlLabels(nameStyle)
+	for i, label := range labels {
+		labels[i] = "    " + quoteSQLLabel(label)
+	}
+
+	return result + fmt.Sprintf("CREATE TYPE %s AS ENUM (\n%s\n);\n", e.SQLTypeName(), strings.Join(labels, ",\n"))
+}
+
+// sqlEnums returns the enums that are stored as Postgres enums, flags are stored as integers.
+func sqlEnums(file *ProtoFile, opts *Options) []*Enum {
+	enums := make([]*Enum, 0, len(file.GetEnums()))
+
+	for _, enum := range file.GetEnums() {
+		if opts.isFlags(enum) {
+			continue
+		}
+
+		enums = append(enums, enum)
+	}
+
+	return enums
+}
+
+// generateSQLFiles generates the DDL with the layout of the options, and the migration
+// from the previous DDL of the options when there is one.
+func generateSQLFiles(files []*ProtoFile, opts *Options) ([]*OutputFile, error) {
+	outputs := make([]*OutputFile, 0)
+	header := "-- Code generated by accessory; DO NOT EDIT.\n-- source: %s\n"
+
+	for _, file := range files {
+		enums := sqlEnums(file, opts)
+
+		if opts.Layout == LayoutFile {
+			if len(enums) == 0 {
+				continue
+			}
+
+			result := fmt.Sprintf(header, file.GetPath())
+			for _, enum := range enums {
+				result = result + "\n" + enum.ToSQL(opts.NameStyle)
+			}
+
+			outputs = append(outputs, &OutputFile{Name: file.outputBase() + ".sql", Content: []byte(result)})
+
+			continue
+		}
+
+		for _, enum := range enums {
+			result := fmt.Sprintf(header, file.GetPath()) + "\n" + enum.ToSQL(opts.NameStyle)
+
+			outputs = append(outputs, &OutputFile{Name: enum.GetTitle() + ".sql", Content: []byte(result)})
+		}
+	}
+
+	if opts.PreviousSQL == "" {
+		return outputs, nil
+	}
+
+	migration, err := generateSQLMigration(files, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(outputs, &OutputFile{Name: SQLMigrationFile, Content: []byte(migration)}), nil
+}
+
+// generateSQLMigration compares the enums to the previous DDL: new types are created and new values
+// are added next to their neighbours in the proto enum. Postgres can't drop enum values,
+// removed values are only pointed out.
+func generateSQLMigration(files []*ProtoFile, opts *Options) (string, error) {
+	previous, err := parseSQLEnums(opts.PreviousSQL)
+	if err != nil {
+		return "", err
+	}
+
+	statements := make([]string, 0)
+
+	for _, file := range files {
+		for _, enum := range sqlEnums(file, opts) {
+			labels := enum.sqlLabels(opts.NameStyle)
+
+			existing, ok := previous[enum.SQLTypeName()]
+			if !ok {
+				statements = append(statements, enum.ToSQL(opts.NameStyle))
+
+				continue
+			}
+
+			known := make(map[string]bool, len(existing))
+			for _, label := range existing {
+				known[label] = true
+			}
+
+			for i, label := range labels {
+				if known[label] {
+					continue
+				}
+
+				statements = append(statements, addSQLValue(enum.SQLTypeName(), label, labels[:i], labels[i+1:], known))
+				known[label] = true
+			}
+
+			current := make(map[string]bool, len(labels))
+			for _, label := range labels {
+				current[label] = true
+			}
+
+			for _, label := range existing {
+				if !current[label] {
+					statements = append(statements, fmt.Sprintf(
+						"-- TODO: %s is no longer in %s, Postgres can't drop enum values, migrate the rows by hand.\n",
+						quoteSQLLabel(label), enum.SQLTypeName()))
+				}
+			}
+		}
+	}
+
+	result := "-- Generated by accessory from the previous DDL, review it before applying.\n" +
+		"-- ALTER TYPE ... ADD VALUE can't be used in a transaction block before Postgres 12.\n"
+
+	if len(statements) == 0 {
+		return result + "\n-- No enum changed since the previous DDL.\n", nil
+	}
+
+	return result + "\n" + strings.Join(statements, "\n"), nil
+}
+
+// addSQLValue adds the label after the closest known label declared before it,
+// or before the closest known label declared after it, so the order follows the proto enum.
+func addSQLValue(typeName, label string, before, after []string, known map[string]bool) string {
+	statement := fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s", typeName, quoteSQLLabel(label))
+
+	for i := len(before) - 1; i >= 0; i-- {
+		if known[before[i]] {
+			return fmt.Sprintf("%s AFTER %s;\n", statement, quoteSQLLabel(before[i]))
+		}
+	}
+
+	for _, next := range after {
+		if known[next] {
+			return fmt.Sprintf("%s BEFORE %s;\n", statement, quoteSQLLabel(next))
+		}
+	}
+
+	return statement + ";\n"
+}
+
+// parseSQLEnums reads the labels of every enum type of the DDL, keyed by the type name.
+func parseSQLEnums(ddl string) (map[string][]string, error) {
+	enums := make(map[string][]string)
+
+	for _, match := range createTypeRegex.FindAllStringSubmatch(ddl, -1) {
+		typeName := strings.ToLower(match[1])
+		if _, ok := enums[typeName]; ok {
+			return nil, fmt.Errorf("type %s is created twice in the previous DDL", typeName)
+		}
+
+		enums[typeName] = parseSQLLabels(match[2])
+	}
+
+	for _, match := range addValueRegex.FindAllStringSubmatch(ddl, -1) {
+		typeName := strings.ToLower(match[1])
+		if _, ok := enums[typeName]; !ok {
+			return nil, fmt.Errorf("a value is added to %s in the previous DDL but the type is never created", typeName)
+		}
+
+		enums[typeName] = append(enums[typeName], parseSQLLabels(match[2])...)
+	}
+
+	return enums, nil
+}
+
+func parseSQLLabels(input string) []string {
+	labels := make([]string, 0)
+
+	for _, match := range sqlLabelRegex.FindAllStringSubmatch(input, -1) {
+		labels = append(labels, strings.ReplaceAll(match[1], "''", "'"))
+	}
+
+	return labels
+}
+
+func quoteSQLLabel(label string) string {
+	return "'" + strings.ReplaceAll(label, "'", "''") + "'"
+}
@@ -0,0 +1,57 @@
+package enum_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateFiles_SQL(t *testing.T) {
+	t.Parallel()
+
+	previous, err := os.ReadFile("testdata/sql/previous.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		nameStyle   string
+		previousSQL string
+	}{
+		"ProtoNames": {
+			nameStyle: enum.NameStyleProto,
+		},
+		"LowerNames": {
+			nameStyle: enum.NameStyleLower,
+		},
+		"Migration": {
+			nameStyle:   enum.NameStyleProto,
+			previousSQL: string(previous),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := enum.LoadProtoFiles([]string{"testdata/sql/reminder.proto"}, []string{"testdata/sql"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				NameStyle:   tt.nameStyle,
+				Layout:      enum.LayoutEnum,
+				Emit:        enum.Emitters{enum.EmitSQL: true},
+				PreviousSQL: tt.previousSQL,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
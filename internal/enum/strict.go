@@ -0,0 +1,101 @@
+package enum
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+// IsValid generates the IsValid method, reporting whether a model value is one of the declared values.
+func (e *Enum) IsValid() string {
+	if e == nil {
+		return ""
+	}
+
+	cases := make([]string, 0, len(e.GetValues()))
+	for _, value := range e.GetValues() {
+		// Aliases would be duplicated cases of the value they alias.
+		if value.IsAlias() {
+			continue
+		}
+
+		cases = append(cases, value.StringValue)
+	}
+
+	return fmt.Sprintf(`
+// IsValid reports whether the %s is one of the declared values.
+func (%s %s) IsValid() bool {
+	switch %s {
+	case %s:
+		return true
+	default:
+		return false
+	}
+}`, e.Title, e.GetReceiver(), e.Title, e.GetReceiver(), strings.Join(cases, ",\n\t\t"))
+}
+
+// ProtoToEnumStrict generates ProtoTo<Enum>Strict, which fails on proto values the model doesn't know
+// instead of falling back to the default value like ProtoTo<Enum> does.
+func (e *Enum) ProtoToEnumStrict() string {
+	if e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+// ProtoTo%sStrict converts from Protobuf version to the %s,
+// it returns an error when the Protobuf value has no %s counterpart.
+func ProtoTo%sStrict(%s %s.%s) (%s, error) {
+	switch %s {%s
+	default:
+		return %s, fmt.Errorf("unknown %s.%s value: %%d", %s)
+	}
+}`, e.Title, e.Title, e.Title,
+		e.Title, e.GetReceiver(), e.GetProtoPackage(), e.GetProtoName(), e.Title,
+		e.GetReceiver(), ConvertValuesToProtoToEnumStrict(e),
+		e.GetDefaultValue().GetStringValue(), e.GetProtoPackage(), e.GetProtoName(), e.GetReceiver())
+}
+
+// GenerateExhaustiveTest generates a test that fails as soon as the proto enum gets a value
+// the model doesn't know about yet. It only needs the standard library, so every style but none gets it.
+func (e *Enum) GenerateExhaustiveTest(style string) string {
+	if e == nil || style == testgen.StyleNone {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+func Test%s_Exhaustive(t *testing.T) {
+	for number, name := range %s.%s_name {
+		if _, err := %s.ProtoTo%sStrict(%s.%s(number)); err != nil {
+			t.Errorf("proto value %%s (%%d) has no %s counterpart: %%v", name, number, err)
+		}
+	}
+}
+`, e.Title, e.GetProtoPackage(), e.GetProtoName(), e.GetModelPackage(), e.Title, e.GetProtoPackage(), e.GetProtoName(), e.Title)
+}
+
+func (v *Value) ProtoToEnumStrict(e *Enum) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+	case %s.%s_%s:
+		return %s, nil`, e.GetProtoPackage(), e.GetProtoValuePrefix(), v.OriginalStringValue, v.StringValue)
+}
+
+// ConvertValuesToProtoToEnumStrict builds one case per value, aliases are skipped
+// because they would be duplicated cases of the value they alias.
+func ConvertValuesToProtoToEnumStrict(e *Enum) string {
+	result := ""
+
+	for _, value := range e.GetValues() {
+		if value.IsAlias() {
+			continue
+		}
+
+		result = result + value.ProtoToEnumStrict(e)
+	}
+
+	return result
+}
@@ -0,0 +1,46 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+)
+
+func TestGenerateFiles_Strict(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		file string
+	}{
+		"Enum": {
+			file: "testdata/text/common/state.proto",
+		},
+		// Aliases are neither valid values of their own nor cases of the strict conversion.
+		"Aliases": {
+			file: "testdata/numbers/priority.proto",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := enum.LoadProtoFiles([]string{tt.file}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				NameStyle: enum.NameStyleProto,
+				Layout:    enum.LayoutEnum,
+				Strict:    true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
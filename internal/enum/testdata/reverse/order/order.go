@@ -0,0 +1,39 @@
+package order
+
+// Status is where an order is in its life.
+type Status int32
+
+const (
+	// StatusPending is set until the payment is captured.
+	StatusPending Status = iota
+	StatusPaid
+	StatusShipped
+	// StatusCancelled can be set at any time.
+	StatusCancelled
+)
+
+// Priority decides how soon an order is shipped.
+type Priority int
+
+const (
+	PriorityUnspecified Priority = 0
+	Low                 Priority = 10
+	High                Priority = 20
+	// Deprecated: use High.
+	Urgent = High
+)
+
+type HTTPMethod uint8
+
+const (
+	HTTPMethodGet  HTTPMethod = 1
+	HTTPMethodPost HTTPMethod = 2
+)
+
+// Order is not an enum, it has no constants.
+type Order struct {
+	status Status
+}
+
+// MaxItems isn't typed with a named integer type.
+const MaxItems = 50
@@ -0,0 +1,52 @@
+package shop
+
+import (
+	"sync"
+	"time"
+)
+
+// Order is what a customer buys.
+type Order struct {
+	mu         sync.Mutex
+	id         string           `accessor:"getter"`
+	items      []*LineItem      `accessor:"getter,setter"`
+	quantities map[string]int32 `accessor:"getter"`
+	status     Status           `accessor:"getter"`
+	createdAt  time.Time        `accessor:"getter"`
+	ttl        time.Duration    `accessor:"getter"`
+	coupon     *string          `accessor:"getter"`
+	shipping   *Address         `accessor:"getter"`
+	tags       []string         `accessor:"getter"`
+	receipt    []byte           `accessor:"getter"`
+	totals     map[int64]*Money `accessor:"getter"`
+}
+
+type LineItem struct {
+	sku      string
+	quantity uint
+	price    Money
+}
+
+type Money struct {
+	currencyCode string
+	units        int64
+	nanos        int32
+}
+
+type Address struct {
+	lines      []string
+	postalCode string
+}
+
+// Status is where an order is in its life.
+type Status int32
+
+const (
+	StatusPending Status = iota + 1
+	StatusPaid
+)
+
+// Channel is not used by the Order.
+type Channel int
+
+const ChannelWeb Channel = 1
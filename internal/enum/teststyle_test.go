@@ -0,0 +1,64 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/masaushi/accessory/internal/enum"
+	"github.com/masaushi/accessory/internal/testgen"
+)
+
+func TestGenerateFiles_TestStyle(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		testStyle string
+	}{
+		"Table": {
+			testStyle: testgen.StyleTable,
+		},
+		"Testify": {
+			testStyle: testgen.StyleTestify,
+		},
+		"None": {
+			testStyle: testgen.StyleNone,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := enum.LoadProtoFiles([]string{"testdata/flags/permission.proto"}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputs, err := enum.GenerateFiles(files, &enum.Options{
+				NameStyle: enum.NameStyleProto,
+				Layout:    enum.LayoutFile,
+				Strict:    true,
+				TestStyle: tt.testStyle,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			snapshotOutputs(t, outputs)
+		})
+	}
+}
+
+func TestOptions_Validate_UnknownTestStyle(t *testing.T) {
+	t.Parallel()
+
+	opts := &enum.Options{
+		NameStyle: enum.NameStyleProto,
+		Layout:    enum.LayoutEnum,
+		TestStyle: "ginkgo",
+	}
+
+	if err := opts.Validate(); err == nil {
+		t.Fatal("expected an error for the unknown test style")
+	}
+}
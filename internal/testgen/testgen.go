@@ -0,0 +1,208 @@
+// Package testgen renders the tests generated along with the code, in the style of the project they go to.
+package testgen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Test styles that can be selected with -test-style.
+const (
+	// StyleGt runs the cases with the gt.Begin/gt.Run/Using harness and testify assertions.
+	StyleGt = "gt"
+	// StyleTable runs the cases as subtests of a table, with the standard library only.
+	StyleTable = "table"
+	// StyleTestify runs the cases as subtests of a testify suite.
+	StyleTestify = "testify"
+	// StyleNone generates no test.
+	StyleNone = "none"
+)
+
+// Styles lists the test styles, the default one first.
+var Styles = []string{StyleGt, StyleTable, StyleTestify, StyleNone}
+
+// Validate checks the test style is a known one.
+func Validate(style string) error {
+	for _, known := range Styles {
+		if style == known {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown test style %q, expected one of %s", style, strings.Join(Styles, ", "))
+}
+
+// Imports are the packages the tests of the style need, the gt harness being left to goimports
+// as it isn't a public module.
+func Imports(style string) []string {
+	switch style {
+	case StyleTable:
+		return []string{"reflect", "testing"}
+	case StyleTestify:
+		return []string{"testing", "github.com/stretchr/testify/suite"}
+	case StyleNone:
+		return nil
+	}
+
+	return []string{"testing", "github.com/stretchr/testify/assert"}
+}
+
+// Test is a generated test, checking every case with the same body.
+type Test struct {
+	// Name is the name of the test without the Test prefix, <Type>_<What> e.g. Order_GetFunctions.
+	Name string
+	// Description tells what the body checks, as the name of the gt run.
+	Description string
+	// Want declares the fields of a case.
+	Want  string
+	Cases []*Case
+	// Body checks a case, reading it from Data and asserting with Equal.
+	Body string
+}
+
+// Case is a case of a test, Data being its keyed fields.
+type Case struct {
+	Name string
+	Data string
+}
+
+type testGenParameters struct {
+	*Test
+	Suite  string
+	Method string
+}
+
+var templates = map[string]string{
+	StyleGt: `
+func Test{{.Name}}(t *testing.T) {
+	type want struct {
+		{{.Want}}
+	}
+
+	type Context struct {
+		testData *want
+	}
+
+	contextInitiateFunction := func(t *testing.T) *Context {
+		return &Context{}
+	}
+
+	gt.Begin(t,
+		contextInitiateFunction,
+		gt.Run("{{.Description}}", func(t *testing.T, ctx *Context) {
+			{{.Body}}
+		}).
+		{{- range $i, $case := .Cases}}{{if $i}}.{{end}}
+			Using("{{$case.Name}}", func(t *testing.T, ctx *Context) {
+				ctx.testData = &want{
+					{{$case.Data}}
+				}
+			})
+		{{- end}},
+	)
+}
+`,
+	StyleTable: `
+func Test{{.Name}}(t *testing.T) {
+	type want struct {
+		{{.Want}}
+	}
+
+	tests := map[string]*want{
+		{{- range .Cases}}
+		"{{.Name}}": {
+			{{.Data}}
+		},
+		{{- end}}
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			{{.Body}}
+		})
+	}
+}
+`,
+	StyleTestify: `
+type {{.Suite}} struct {
+	suite.Suite
+}
+
+func Test{{.Name}}(t *testing.T) {
+	suite.Run(t, new({{.Suite}}))
+}
+
+func (s *{{.Suite}}) {{.Method}}() {
+	type want struct {
+		{{.Want}}
+	}
+
+	tests := map[string]*want{
+		{{- range .Cases}}
+		"{{.Name}}": {
+			{{.Data}}
+		},
+		{{- end}}
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		s.Run(name, func() {
+			{{.Body}}
+		})
+	}
+}
+`,
+}
+
+// Render generates the test in the style, nothing for StyleNone.
+func Render(style string, test *Test) (string, error) {
+	if style == StyleNone {
+		return "", nil
+	}
+
+	tpl, ok := templates[style]
+	if !ok {
+		return "", Validate(style)
+	}
+
+	// Order_GetFunctions runs the suite OrderGetFunctionsSuite with the method TestGetFunctions.
+	_, what, _ := strings.Cut(test.Name, "_")
+
+	t := template.Must(template.New(style).Parse(tpl))
+	buf := new(bytes.Buffer)
+
+	if err := t.Execute(buf, &testGenParameters{
+		Test:   test,
+		Suite:  strings.ReplaceAll(test.Name, "_", "") + "Suite",
+		Method: "Test" + what,
+	}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Data is the expression of the current case in the body of the test.
+func Data(style string) string {
+	if style == StyleGt {
+		return "ctx.testData"
+	}
+
+	return "tt"
+}
+
+// Equal generates the assertion that got is equal to want.
+func Equal(style, want, got string) string {
+	switch style {
+	case StyleTable:
+		return fmt.Sprintf("if !reflect.DeepEqual(%[2]s, %[1]s) {\n\tt.Errorf(\"%[2]s = %%v, want %%v\", %[2]s, %[1]s)\n}", want, got)
+	case StyleTestify:
+		return fmt.Sprintf("s.Equal(%s, %s)", want, got)
+	}
+
+	return fmt.Sprintf("assert.Equal(t, %s, %s)", want, got)
+}